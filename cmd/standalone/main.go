@@ -1,28 +1,95 @@
 package main
 
 import (
+	"context"
 	"flag"
-	"io/ioutil"
+	"log"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/RemyRanger/taktyl_core_grpc/src/config"
 	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
-	"google.golang.org/grpc/grpclog"
-)
-
-var serverAddress = flag.String(
-	"server-address",
-	"dns:///0.0.0.0:10000",
-	"The address to the gRPC server, in the gRPC standard naming format. "+
-		"See https://github.com/grpc/grpc/blob/master/doc/naming.md for more information.",
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/metrics"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tlsconfig"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tracing"
+	"go.uber.org/zap"
 )
 
 func main() {
-	flag.Parse()
+	// The gRPC server to proxy to is set with --server-address or $SERVER_ADDRESS,
+	// the port to listen on with --gateway-port or $GATEWAY_PORT, and metrics
+	// with --gateway-metrics-addr or $GATEWAY_METRICS_ADDR
+	cfg, printConfig, err := config.Load(os.Args[0], os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalln("Invalid configuration:", err)
+	}
+	if printConfig {
+		if err := cfg.PrintYAML(os.Stdout); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	// JSON logs, gRPC internal logs included at debug level
+	logger, err := logging.New(cfg.Log.Level)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer logger.Sync()
+	logging.ReplaceGlobals(logger)
+
+	// Traces start here and continue on the gRPC server
+	shutdownTracing, err := tracing.Setup(cfg.Tracing.Options())
+	if err != nil {
+		logger.Fatal("Failed to set tracing up", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	var certs *tlsconfig.Reloader
+	if !cfg.Server.ServeHTTP {
+		certs, err = tlsconfig.Load(cfg.TLS.Files(), time.Duration(cfg.TLS.ReloadInterval))
+		if err != nil {
+			logger.Fatal("Failed to load TLS certificates", zap.Error(err))
+		}
+		defer certs.Close()
+	}
+
+	// Metrics are served apart so that they are not exposed with the API, nor
+	// clash with those of the server run on the same host
+	if cfg.Gateway.MetricsAddr != "" {
+		metricsLis, err := net.Listen("tcp", cfg.Gateway.MetricsAddr)
+		if err != nil {
+			logger.Fatal("Failed to listen for metrics", zap.Error(err))
+		}
+		metricsSrv := metrics.NewServer()
+		defer metricsSrv.Shutdown(context.Background())
+		go func() {
+			if err := metricsSrv.Serve(metricsLis); err != nil {
+				logger.Fatal("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+	}
 
-	// Adds gRPC internal logs. This is quite verbose, so adjust as desired!
-	log := grpclog.NewLoggerV2(os.Stdout, ioutil.Discard, ioutil.Discard)
-	grpclog.SetLoggerV2(log)
+	// Drain in-flight requests on SIGINT or SIGTERM
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-stop
+		logger.Info("Shutting down", zap.Stringer("signal", sig))
+		cancel()
+	}()
 
-	err := gateway.Run(*serverAddress)
-	log.Fatalln(err)
+	err = gateway.Run(ctx, cfg.Gateway.ServerAddress, cfg.Gateway.Addr(cfg.Server), certs, time.Duration(cfg.Server.ShutdownTimeout))
+	if err != nil {
+		logger.Fatal("Failed to run the gateway", zap.Error(err))
+	}
+	logger.Info("Gateway stopped")
 }
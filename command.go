@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/config"
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// commandSetup loads the configuration of a command from args, sets the
+// logger up and connects to the database. The memory storage, which only
+// lives in the server, is refused. Callers close the database and sync the
+// logger.
+func commandSetup(name string, args []string) (config.Config, *zap.Logger, *gorm.DB) {
+	cfg, _, err := config.Load(name, args)
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalln("Invalid configuration:", err)
+	}
+	logger, err := logging.New(cfg.Log.Level)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	logging.ReplaceGlobals(logger)
+
+	if cfg.DB.Driver == storage.MemoryDriver {
+		logger.Fatal("The memory storage lives in the server, commands cannot reach it")
+	}
+	return cfg, logger, connect(cfg, logger)
+}
+
+// connect connects to the database of cfg, retrying for the connect timeout
+// while it does not accept connections, and exits if it never does.
+func connect(cfg config.Config, logger *zap.Logger) *gorm.DB {
+	return connectTo(cfg, logger, cfg.DB.Host, strconv.Itoa(cfg.DB.Port))
+}
+
+// connectReplicas connects to the read replicas of cfg like connect.
+func connectReplicas(cfg config.Config, logger *zap.Logger) []*gorm.DB {
+	replicas := make([]*gorm.DB, len(cfg.DB.Replicas))
+	for i, addr := range cfg.DB.Replicas {
+		// Validated by config.Load
+		host, port, _ := net.SplitHostPort(addr)
+		replicas[i] = connectTo(cfg, logger.With(zap.String("replica", addr)), host, port)
+	}
+	return replicas
+}
+
+func connectTo(cfg config.Config, logger *zap.Logger, host, port string) *gorm.DB {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.DB.ConnectTimeout))
+	defer cancel()
+	db, err := storage.Connect(ctx, cfg.DB.Driver, cfg.DB.User, cfg.DB.Password, port, host, cfg.DB.Name, cfg.DB.Pool(),
+		func(err error, wait time.Duration) {
+			logger.Warn("Cannot connect to the database yet, retrying", zap.String("driver", cfg.DB.Driver), zap.Duration("wait", wait), zap.Error(err))
+		},
+	)
+	if err != nil {
+		logger.Fatal("Cannot connect to the database", zap.String("driver", cfg.DB.Driver), zap.Error(err))
+	}
+	return db
+}
@@ -1,20 +1,40 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/RemyRanger/taktyl_core_grpc/src/cache"
+	"github.com/RemyRanger/taktyl_core_grpc/src/config"
 	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
-	"github.com/RemyRanger/taktyl_core_grpc/src/seed"
+	"github.com/RemyRanger/taktyl_core_grpc/src/healthcheck"
+	"github.com/RemyRanger/taktyl_core_grpc/src/idempotency"
+	"github.com/RemyRanger/taktyl_core_grpc/src/identity"
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/metrics"
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
 	"github.com/RemyRanger/taktyl_core_grpc/src/server"
-	"github.com/joho/godotenv"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tlsconfig"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tracing"
+	"github.com/RemyRanger/taktyl_core_grpc/src/validation"
 
 	// Proto Injects
 	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
@@ -25,47 +45,272 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			migrateCommand(os.Args[0], os.Args[2:])
+			return
+		case "seed":
+			seedCommand(os.Args[0], os.Args[2:])
+			return
+		}
+	}
+
+	// Settings come from flags, the environment, .env and the --config file
+	cfg, printConfig, err := config.Load(os.Args[0], os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalln("Invalid configuration:", err)
+	}
+	if printConfig {
+		if err := cfg.PrintYAML(os.Stdout); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
 
-	// Setting env values
-	var err error
-	err = godotenv.Load()
+	// JSON logs, gRPC internal logs included at debug level
+	logger, err := logging.New(cfg.Log.Level)
 	if err != nil {
-		log.Fatalf("Error getting env, not comming through %v", err)
+		log.Fatalln(err)
+	}
+	defer logger.Sync()
+	logging.ReplaceGlobals(logger)
+
+	// Spans are exported to stdout or an OpenTelemetry collector, see $TRACING_EXPORTER
+	shutdownTracing, err := tracing.Setup(cfg.Tracing.Options())
+	if err != nil {
+		logger.Fatal("Failed to set tracing up", zap.Error(err))
+	}
+
+	// Orchestrators see the server NOT_SERVING until the database is connected and migrated
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// Users and events are kept in memory with $DB_DRIVER=memory, in the database otherwise
+	var (
+		userRepository   storage.UserRepository
+		eventRepository  storage.EventRepository
+		unitOfWork       storage.UnitOfWork
+		idempotencyStore idempotency.Store
+		pinger           healthcheck.Pinger
+		closeStorage     func() error
+	)
+	if cfg.DB.Driver == storage.MemoryDriver {
+		mem := storage.NewMemory()
+		userRepository = storage.NewMemoryUserRepository(mem)
+		eventRepository = storage.NewMemoryEventRepository(mem)
+		unitOfWork = storage.NewMemoryUnitOfWork(mem)
+		idempotencyStore = idempotency.NewMemoryStore()
+		pinger = mem
+		closeStorage = func() error { return nil }
+		logger.Warn("Users and events are kept in memory and lost on restart")
 	} else {
-		fmt.Println("We are getting the env values")
+		db := connect(cfg, logger)
+		logger.Info("Connected to the database", zap.String("driver", cfg.DB.Driver))
+		if err := checkSchema(db, cfg); errors.Is(err, migrate.ErrBehind) {
+			logger.Fatal("Refusing to start, apply the migrations with the migrate up command", zap.Error(err))
+		} else if err != nil {
+			logger.Fatal("Cannot check the database schema", zap.Error(err))
+		}
+		// Reads of users and events may go to replicas, writes go to db
+		replicas := connectReplicas(cfg, logger)
+		if len(replicas) > 0 {
+			logger.Info("Connected to the read replicas", zap.Strings("replicas", cfg.DB.Replicas))
+		}
+		for i, instance := range append([]*gorm.DB{db}, replicas...) {
+			name := "primary"
+			if i > 0 {
+				name = fmt.Sprintf("replica-%d", i-1)
+			}
+			metrics.InstrumentGorm(instance, name)
+			tracing.InstrumentGorm(instance, cfg.DB.Driver)
+			if cfg.Log.SQL {
+				logging.InstrumentGorm(instance)
+			}
+		}
+		userRepository = storage.NewGormUserRepository(db, replicas...)
+		eventRepository = storage.NewGormEventRepository(db, replicas...)
+		unitOfWork = storage.NewGormUnitOfWork(db)
+		// Users and events got by ID are cached, writes dropping them
+		if cfg.Cache.Size > 0 {
+			cached := storage.NewCached(cache.NewLRU(cfg.Cache.Size), time.Duration(cfg.Cache.TTL))
+			cached.Observe = metrics.ObserveCache
+			userRepository = cached.Users(userRepository)
+			eventRepository = cached.Events(eventRepository)
+			unitOfWork = cached.UnitOfWork(unitOfWork)
+		}
+		idempotencyStore = idempotency.NewGormStore(db)
+		pinger = db.DB()
+		closeStorage = func() error {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return db.Close()
+		}
 	}
 
-	// Adds gRPC internal logs. This is quite verbose, so adjust as desired!
-	log := grpclog.NewLoggerV2(os.Stdout, ioutil.Discard, ioutil.Discard)
-	grpclog.SetLoggerV2(log)
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+	backend.MaxBatchSize = cfg.API.MaxBatchSize
+	backend.ReadYourWrites = time.Duration(cfg.DB.ReadYourWrites)
 
-	addr := "0.0.0.0:10000"
-	lis, err := net.Listen("tcp", addr)
+	// Certificates are reloaded from the TLS files when they change
+	var certs *tlsconfig.Reloader
+	if !cfg.Server.ServeHTTP {
+		certs, err = tlsconfig.Load(cfg.TLS.Files(), time.Duration(cfg.TLS.ReloadInterval))
+		if err != nil {
+			logger.Fatal("Failed to load TLS certificates", zap.Error(err))
+		}
+		defer certs.Close()
+	}
+
+	// Token buckets are kept in memory, each instance enforces the limits on its own
+	limiter := ratelimit.NewMemoryStore(ratelimit.DefaultMaxBuckets)
+	limits := cfg.RateLimit.Limits()
+	trustedNetwork, err := ratelimit.Networks(cfg.RateLimit.TrustedProxies)
 	if err != nil {
-		log.Fatalln("Failed to listen:", err)
+		logger.Fatal("Invalid trusted proxy networks", zap.Error(err))
 	}
+	// Over TLS, the embedded gateway is trusted by its certificate, the server's own
+	clientKey := ratelimit.DefaultKey(certs.IsOwnCertificate, trustedNetwork, ratelimit.APIKeys(cfg.RateLimit.APIKeys))
+	// Clients read their writes as identified for rate limiting
+	backend.ClientKey = clientKey
 
+	// TLS is terminated by the HTTP server shared with the gateway, see gateway.Serve
 	s := grpc.NewServer(
-	// TODO: Replace with your own certificate!
-	//grpc.Creds(credentials.NewServerTLSFromCert(&insecure.Cert)),
+		grpc.ChainUnaryInterceptor(
+			// Continue the trace of the caller, the gateway's included
+			otelgrpc.UnaryServerInterceptor(),
+			// Log every RPC with its request ID
+			logging.UnaryServerInterceptor(logger),
+			// Count every RPC, rejected ones included
+			metrics.UnaryServerInterceptor(),
+			// Attribute gateway requests to the client certificate it forwards
+			identity.UnaryServerInterceptor(certs.IsOwnCertificate),
+			// Limit the requests of each client, identified once authenticated
+			ratelimit.UnaryServerInterceptor(limiter, limits, clientKey),
+			// Reject invalid requests before they reach the backend
+			validation.UnaryServerInterceptor(),
+			idempotency.UnaryServerInterceptor(
+				idempotencyStore,
+				time.Duration(cfg.API.IdempotencyTTL),
+				time.Duration(cfg.API.IdempotencyLease),
+				clientKey,
+				"/user.UserService/AddUser",
+				"/user.UserService/BatchAddUsers",
+				"/event.EventService/AddEvent",
+				"/event.EventService/BatchAddEvents",
+			),
+		),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger),
+			metrics.StreamServerInterceptor(),
+			identity.StreamServerInterceptor(certs.IsOwnCertificate),
+			ratelimit.StreamServerInterceptor(limiter, limits, clientKey),
+			validation.StreamServerInterceptor(),
+		),
 	)
 
-	backend := server.New().Initialize(os.Getenv("DB_DRIVER"), os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), os.Getenv("DB_PORT"), os.Getenv("DB_HOST"), os.Getenv("DB_NAME"))
-
 	pbUser.RegisterUserServiceServer(s, backend)
 	pbEvent.RegisterEventServiceServer(s, backend)
+	healthpb.RegisterHealthServer(s, healthSrv)
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
+	metrics.RegisterServer(s)
+
+	// Fixtures are opt-in, see $SEED_SETS and the seed command
+	if len(cfg.Seed.Sets) > 0 {
+		seedSets(context.Background(), logger, cfg.Seed.Dir, cfg.Seed.Sets, unitOfWork)
+	}
+
+	// Expired idempotency records are deleted in the background
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	go idempotency.Sweep(sweepCtx, idempotencyStore, idempotency.SweepInterval)
+
+	// Report SERVING while the database answers pings
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+	go healthcheck.Monitor(healthCtx, healthSrv, pinger, time.Duration(cfg.Server.HealthCheckInterval),
+		"user.UserService", "event.EventService")
 
-	seed.Load(backend.DB)
+	addr := cfg.Server.Addr()
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Fatal("Failed to listen", zap.Error(err))
+	}
 
-	// Serve gRPC Server
-	log.Info("Serving gRPC on https://", addr)
-	go func() {
-		log.Fatal(s.Serve(lis))
-	}()
+	// The gateway proxies REST requests to the gRPC server through the same port
+	conn, err := gateway.Dial(context.Background(), "dns:///"+addr, certs)
+	if err != nil {
+		logger.Fatal("Failed to dial the server", zap.Error(err))
+	}
+	gw, err := gateway.New(context.Background(), conn)
+	if err != nil {
+		logger.Fatal("Failed to create the gateway", zap.Error(err))
+	}
+
+	// Metrics are served apart so that they are not exposed with the API
+	var metricsSrv *metrics.Server
+	if cfg.Server.MetricsAddr != "" {
+		metricsLis, err := net.Listen("tcp", cfg.Server.MetricsAddr)
+		if err != nil {
+			logger.Fatal("Failed to listen for metrics", zap.Error(err))
+		}
+		metricsSrv = metrics.NewServer()
+		go func() {
+			if err := metricsSrv.Serve(metricsLis); err != nil {
+				logger.Fatal("Failed to serve metrics", zap.Error(err))
+			}
+		}()
+	}
 
-	err = gateway.Run("dns:///" + addr)
-	log.Fatalln(err)
+	// Serve gRPC, gRPC-Web, the gRPC-Gateway and the OpenAPI UI on a single port
+	srv := gateway.NewServer(gateway.Multiplex(s, gw), certs)
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(lis) }()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-errc:
+		logger.Fatal("Failed to serve", zap.Error(err))
+	case sig := <-stop:
+		logger.Info("Shutting down", zap.Stringer("signal", sig))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancel()
+
+	// Fail readiness checks while draining
+	stopHealth()
+	healthSrv.Shutdown()
+
+	// Watch streams never end on their own, end them so that draining does not wait for them
+	backend.CancelStreams()
+
+	// gRPC requests are served through the HTTP server: once it is drained
+	// GracefulStop has nothing left to wait for, otherwise Stop cancels the
+	// RPCs still running.
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to drain in-flight requests", zap.Error(err))
+		s.Stop()
+	} else {
+		s.GracefulStop()
+	}
+
+	conn.Close()
+	if metricsSrv != nil {
+		metricsSrv.Shutdown(ctx)
+	}
+	if err := closeStorage(); err != nil {
+		logger.Warn("Failed to close the database", zap.Error(err))
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
+	}
+	logger.Info("Server stopped")
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/config"
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// migrateCommand runs `migrate up|down|status`, the configuration flags
+// following the action.
+func migrateCommand(name string, args []string) {
+	if len(args) == 0 || (args[0] != "up" && args[0] != "down" && args[0] != "status") {
+		fmt.Fprintf(os.Stderr, "Usage: %s migrate up|down|status [flags]\n", name)
+		fmt.Fprintln(os.Stderr, "  up      applies the pending migrations")
+		fmt.Fprintln(os.Stderr, "  down    reverts the last applied migration")
+		fmt.Fprintln(os.Stderr, "  status  lists the migrations and when they were applied")
+		os.Exit(2)
+	}
+	action := args[0]
+
+	cfg, logger, db := commandSetup(name+" migrate "+action, args[1:])
+	defer logger.Sync()
+	defer db.Close()
+	migrator, err := migrate.New(db, cfg.DB.Driver)
+	if err != nil {
+		logger.Fatal("Cannot load the migrations", zap.Error(err))
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			logger.Info("Applied migration", zap.Int("version", m.Version), zap.String("name", m.Name))
+		}
+		if err != nil {
+			logger.Fatal("Failed to migrate the database", zap.Error(err))
+		}
+		if len(applied) == 0 {
+			logger.Info("Database schema is up to date")
+		}
+	case "down":
+		reverted, err := migrator.Down()
+		if err != nil {
+			logger.Fatal("Failed to revert the last migration", zap.Error(err))
+		}
+		if reverted == nil {
+			logger.Info("No migration to revert")
+			return
+		}
+		logger.Info("Reverted migration", zap.Int("version", reverted.Version), zap.String("name", reverted.Name))
+	case "status":
+		list, err := migrator.Status()
+		if err != nil {
+			logger.Fatal("Failed to read the applied migrations", zap.Error(err))
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range list {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		w.Flush()
+	}
+}
+
+// checkSchema fails if the migrations of the binary are not all applied to
+// db. A SQLite database kept in memory, which no `migrate` command can reach,
+// is migrated instead.
+func checkSchema(db *gorm.DB, cfg config.Config) error {
+	migrator, err := migrate.New(db, cfg.DB.Driver)
+	if err != nil {
+		return err
+	}
+	if cfg.DB.Driver == storage.SQLiteDriver && cfg.DB.Name == storage.SQLiteMemory {
+		_, err := migrator.Up()
+		return err
+	}
+	return migrator.Check()
+}
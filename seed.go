@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/seed"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// seedCommand runs `seed [set...]`, the configuration flags following the
+// sets. Without sets, those of the configuration are seeded.
+func seedCommand(name string, args []string) {
+	var sets []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		sets = append(sets, args[0])
+		args = args[1:]
+	}
+
+	cfg, logger, db := commandSetup(name+" seed", args)
+	defer logger.Sync()
+	defer db.Close()
+	if len(sets) == 0 {
+		sets = cfg.Seed.Sets
+	}
+	if len(sets) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: %s seed set... [flags]\n", name)
+		fmt.Fprintf(os.Stderr, "  upserts the fixtures of the sets, directories of %s, or of $SEED_SETS\n", cfg.Seed.Dir)
+		os.Exit(2)
+	}
+
+	migrator, err := migrate.New(db, cfg.DB.Driver)
+	if err != nil {
+		logger.Fatal("Cannot load the migrations", zap.Error(err))
+	}
+	if err := migrator.Check(); err != nil {
+		logger.Fatal("Cannot seed the database", zap.Error(err))
+	}
+
+	seedSets(context.Background(), logger, cfg.Seed.Dir, sets, storage.NewGormUnitOfWork(db))
+}
+
+// seedSets upserts the fixture sets of dir, exiting on failure.
+func seedSets(ctx context.Context, logger *zap.Logger, dir string, sets []string, work storage.UnitOfWork) {
+	result, err := seed.Run(ctx, dir, sets, work)
+	fields := []zap.Field{
+		zap.Strings("sets", sets),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("unchanged", result.Unchanged),
+	}
+	if err != nil {
+		logger.Fatal("Failed to seed the fixtures", append(fields, zap.Error(err))...)
+	}
+	logger.Info("Seeded the fixtures", fields...)
+}
@@ -0,0 +1,19 @@
+// Package cache keeps values for a while in front of slower stores.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache keeps values, serialized by their callers, for a while. Implementations
+// shared between instances, for instance on Redis, let the invalidations of
+// one instance reach the others.
+type Cache interface {
+	// Get returns the value of key, and false if it is missing or expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set keeps value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete drops the values of keys, missing ones being ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
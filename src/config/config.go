@@ -0,0 +1,633 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v2"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tlsconfig"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tracing"
+)
+
+// redacted replaces secrets in PrintYAML.
+const redacted = "REDACTED"
+
+// Config holds the settings of the server and of the standalone gateway.
+type Config struct {
+	Server    Server    `yaml:"server"`
+	TLS       TLS       `yaml:"tls"`
+	DB        DB        `yaml:"db"`
+	API       API       `yaml:"api"`
+	Gateway   Gateway   `yaml:"gateway"`
+	Tracing   Tracing   `yaml:"tracing"`
+	Log       Log       `yaml:"log"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	Seed      Seed      `yaml:"seed"`
+	Cache     Cache     `yaml:"cache"`
+}
+
+// Server configures the listener shared by gRPC, gRPC-Web and the gateway.
+type Server struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	// ServeHTTP serves cleartext HTTP/1 and h2c instead of TLS.
+	ServeHTTP bool `yaml:"serve_http"`
+	// ShutdownTimeout bounds how long in-flight requests are drained for on
+	// SIGINT or SIGTERM.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout"`
+	// HealthCheckInterval is how often the database is pinged to report the
+	// health of the server.
+	HealthCheckInterval Duration `yaml:"health_check_interval"`
+	// MetricsAddr is the address /metrics is served on, apart from the API.
+	// Metrics are not served when empty.
+	MetricsAddr string `yaml:"metrics_addr"`
+}
+
+// Addr returns the address to listen on.
+func (s Server) Addr() string {
+	return fmt.Sprintf("%s:%d", s.Host, s.Port)
+}
+
+// TLS names the certificate files, see tlsconfig.Files.
+type TLS struct {
+	CertFile       string   `yaml:"cert_file"`
+	KeyFile        string   `yaml:"key_file"`
+	CAFile         string   `yaml:"ca_file"`
+	ClientAuth     bool     `yaml:"client_auth"`
+	ServerName     string   `yaml:"server_name"`
+	ReloadInterval Duration `yaml:"reload_interval"`
+}
+
+// Files returns the certificate files to load with tlsconfig.Load.
+func (t TLS) Files() tlsconfig.Files {
+	return tlsconfig.Files{
+		CertFile:   t.CertFile,
+		KeyFile:    t.KeyFile,
+		CAFile:     t.CAFile,
+		ClientAuth: t.ClientAuth,
+		ServerName: t.ServerName,
+	}
+}
+
+// DB configures the database connection.
+type DB struct {
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime configure the pool of
+	// connections, see storage.Pool.
+	MaxOpenConns    int      `yaml:"max_open_conns"`
+	MaxIdleConns    int      `yaml:"max_idle_conns"`
+	ConnMaxLifetime Duration `yaml:"conn_max_lifetime"`
+	// ConnectTimeout bounds how long connecting is retried for on startup,
+	// see storage.Connect.
+	ConnectTimeout Duration `yaml:"connect_timeout"`
+	// Replicas are the host:port addresses of read replicas, reached with the
+	// user, password and name of the primary.
+	Replicas []string `yaml:"replicas"`
+	// ReadYourWrites is how long the reads of a client go to the primary
+	// after it wrote, see server.Backend.
+	ReadYourWrites Duration `yaml:"read_your_writes"`
+}
+
+// Pool returns the pool of connections to open the database with.
+func (d DB) Pool() storage.Pool {
+	return storage.Pool{
+		MaxOpenConns:    d.MaxOpenConns,
+		MaxIdleConns:    d.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(d.ConnMaxLifetime),
+	}
+}
+
+// Seed configures the fixtures, see seed.Run.
+type Seed struct {
+	// Dir holds a directory of fixture files per set.
+	Dir string `yaml:"dir"`
+	// Sets are seeded on startup, none by default.
+	Sets []string `yaml:"sets"`
+}
+
+// Cache configures the cache of GetUser and GetEvent, see storage.Cached.
+type Cache struct {
+	// Size is the number of records kept in memory, the cache being disabled
+	// when 0.
+	Size int `yaml:"size"`
+	// TTL is how long records are kept for.
+	TTL Duration `yaml:"ttl"`
+}
+
+// API configures the behaviour of the RPCs.
+type API struct {
+	MaxBatchSize   int      `yaml:"max_batch_size"`
+	IdempotencyTTL Duration `yaml:"idempotency_ttl"`
+	// IdempotencyLease is how long a request holds its Idempotency-Key while
+	// running, see idempotency.UnaryServerInterceptor.
+	IdempotencyLease Duration `yaml:"idempotency_lease"`
+}
+
+// Gateway configures the standalone gateway.
+type Gateway struct {
+	// ServerAddress is the gRPC server to proxy to, in the gRPC naming format.
+	ServerAddress string `yaml:"server_address"`
+	// Port is the port the standalone gateway listens on, on the server host,
+	// apart from the server it proxies to.
+	Port int `yaml:"port"`
+	// MetricsAddr is the address the standalone gateway serves /metrics on,
+	// apart from that of the server. Metrics are not served when empty.
+	MetricsAddr string `yaml:"metrics_addr"`
+}
+
+// Addr returns the address the standalone gateway listens on.
+func (g Gateway) Addr(s Server) string {
+	return fmt.Sprintf("%s:%d", s.Host, g.Port)
+}
+
+// Tracing configures the export of OpenTelemetry spans, see tracing.Options.
+type Tracing struct {
+	ServiceName  string  `yaml:"service_name"`
+	Exporter     string  `yaml:"exporter"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	OTLPInsecure bool    `yaml:"otlp_insecure"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+}
+
+// Options returns the options to set tracing up with.
+func (t Tracing) Options() tracing.Options {
+	return tracing.Options{
+		ServiceName:  t.ServiceName,
+		Exporter:     t.Exporter,
+		OTLPEndpoint: t.OTLPEndpoint,
+		OTLPInsecure: t.OTLPInsecure,
+		SampleRatio:  t.SampleRatio,
+	}
+}
+
+// RateLimit configures the token buckets limiting the RPCs of each client,
+// see ratelimit.Limits.
+type RateLimit struct {
+	// Rate, in requests per second, and Burst apply to the methods missing
+	// from Methods. A zero Rate does not limit.
+	Rate    float64                `yaml:"rate"`
+	Burst   int                    `yaml:"burst"`
+	Methods map[string]MethodLimit `yaml:"methods"`
+	// APIKeys are the keys identifying clients by their X-API-Key, see
+	// ratelimit.DefaultKey. Other keys are ignored.
+	APIKeys []string `yaml:"api_keys"`
+	// TrustedProxies are the networks, such as 10.0.0.0/8, of the proxies
+	// whose X-Forwarded-For identifies clients, see ratelimit.DefaultKey.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// MethodLimit is the limit of a method, named like "/event.EventService/AddEvent".
+type MethodLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// Limits returns the limits to enforce.
+func (r RateLimit) Limits() ratelimit.Limits {
+	limits := ratelimit.Limits{
+		Default: ratelimit.Limit{Rate: r.Rate, Burst: r.Burst},
+		Methods: make(map[string]ratelimit.Limit, len(r.Methods)),
+	}
+	for method, l := range r.Methods {
+		limits.Methods[method] = ratelimit.Limit{Rate: l.Rate, Burst: l.Burst}
+	}
+	return limits
+}
+
+// Log configures the logs, written to stdout as JSON lines.
+type Log struct {
+	// Level is the lowest level logged: debug, info, warn or error.
+	Level string `yaml:"level"`
+	// SQL logs every query, without the values bound to it.
+	SQL bool `yaml:"sql"`
+}
+
+// Duration is a time.Duration read and written as a string such as "24h".
+type Duration time.Duration
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the configuration used for settings that are not set.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Host:                "0.0.0.0",
+			Port:                11000,
+			ShutdownTimeout:     Duration(30 * time.Second),
+			HealthCheckInterval: Duration(5 * time.Second),
+			MetricsAddr:         "0.0.0.0:9090",
+		},
+		TLS: TLS{
+			ReloadInterval: Duration(10 * time.Second),
+		},
+		DB: DB{
+			Driver:          "postgres",
+			Host:            "127.0.0.1",
+			Port:            5432,
+			MaxOpenConns:    20,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnectTimeout:  Duration(30 * time.Second),
+			ReadYourWrites:  Duration(5 * time.Second),
+		},
+		Seed: Seed{
+			Dir: "fixtures",
+		},
+		Cache: Cache{
+			Size: 10000,
+			TTL:  Duration(30 * time.Second),
+		},
+		API: API{
+			MaxBatchSize:     100,
+			IdempotencyTTL:   Duration(24 * time.Hour),
+			IdempotencyLease: Duration(time.Minute),
+		},
+		Gateway: Gateway{
+			ServerAddress: "dns:///0.0.0.0:11000",
+			Port:          11001,
+			MetricsAddr:   "0.0.0.0:9091",
+		},
+		Tracing: Tracing{
+			ServiceName:  "taktyl-core",
+			OTLPEndpoint: "localhost:55680",
+			SampleRatio:  1,
+		},
+		Log: Log{
+			Level: "info",
+		},
+	}
+}
+
+// option binds a setting to its environment variable and flag.
+type option struct {
+	env   string
+	flag  string
+	usage string
+	value func(c *Config) flag.Value
+}
+
+var options = []option{
+	{"SERVER_HOST", "host", "Address to listen on", func(c *Config) flag.Value { return (*stringValue)(&c.Server.Host) }},
+	{"PORT", "port", "Port to listen on", func(c *Config) flag.Value { return (*intValue)(&c.Server.Port) }},
+	{"SERVE_HTTP", "serve-http", "Serve cleartext HTTP/1 and h2c instead of TLS", func(c *Config) flag.Value { return (*boolValue)(&c.Server.ServeHTTP) }},
+	{"SHUTDOWN_TIMEOUT", "shutdown-timeout", "How long in-flight requests are drained for on shutdown", func(c *Config) flag.Value { return (*durationValue)(&c.Server.ShutdownTimeout) }},
+	{"HEALTH_CHECK_INTERVAL", "health-check-interval", "How often the database is pinged to report the server health", func(c *Config) flag.Value { return (*durationValue)(&c.Server.HealthCheckInterval) }},
+	{"METRICS_ADDR", "metrics-addr", "Address /metrics is served on, disabled when empty", func(c *Config) flag.Value { return (*stringValue)(&c.Server.MetricsAddr) }},
+	{"TLS_CERT_FILE", "tls-cert-file", "Certificate to serve, a self signed one is used when unset", func(c *Config) flag.Value { return (*stringValue)(&c.TLS.CertFile) }},
+	{"TLS_KEY_FILE", "tls-key-file", "Private key of the certificate", func(c *Config) flag.Value { return (*stringValue)(&c.TLS.KeyFile) }},
+	{"TLS_CA_FILE", "tls-ca-file", "CAs verifying clients and the gateway's dial, the system roots when unset", func(c *Config) flag.Value { return (*stringValue)(&c.TLS.CAFile) }},
+	{"TLS_CLIENT_AUTH", "tls-client-auth", "Require client certificates signed by the CA file", func(c *Config) flag.Value { return (*boolValue)(&c.TLS.ClientAuth) }},
+	{"TLS_SERVER_NAME", "tls-server-name", "Name the gateway verifies the server certificate against", func(c *Config) flag.Value { return (*stringValue)(&c.TLS.ServerName) }},
+	{"TLS_RELOAD_INTERVAL", "tls-reload-interval", "How often certificate files are checked for changes", func(c *Config) flag.Value { return (*durationValue)(&c.TLS.ReloadInterval) }},
+	{"DB_DRIVER", "db-driver", "Database driver", func(c *Config) flag.Value { return (*stringValue)(&c.DB.Driver) }},
+	{"DB_HOST", "db-host", "Database host", func(c *Config) flag.Value { return (*stringValue)(&c.DB.Host) }},
+	{"DB_PORT", "db-port", "Database port", func(c *Config) flag.Value { return (*intValue)(&c.DB.Port) }},
+	{"DB_USER", "db-user", "Database user", func(c *Config) flag.Value { return (*stringValue)(&c.DB.User) }},
+	{"DB_PASSWORD", "db-password", "Database password", func(c *Config) flag.Value { return (*stringValue)(&c.DB.Password) }},
+	{"DB_NAME", "db-name", "Database name", func(c *Config) flag.Value { return (*stringValue)(&c.DB.Name) }},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "Maximum number of open database connections, unlimited when 0", func(c *Config) flag.Value { return (*intValue)(&c.DB.MaxOpenConns) }},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "Maximum number of idle database connections kept open", func(c *Config) flag.Value { return (*intValue)(&c.DB.MaxIdleConns) }},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "How long a database connection is reused for, forever when 0", func(c *Config) flag.Value { return (*durationValue)(&c.DB.ConnMaxLifetime) }},
+	{"DB_CONNECT_TIMEOUT", "db-connect-timeout", "How long connecting to the database is retried for on startup", func(c *Config) flag.Value { return (*durationValue)(&c.DB.ConnectTimeout) }},
+	{"DB_REPLICAS", "db-replicas", "Comma separated host:port addresses of read replicas of the database", func(c *Config) flag.Value { return (*stringsValue)(&c.DB.Replicas) }},
+	{"DB_READ_YOUR_WRITES", "db-read-your-writes", "How long the reads of a client go to the primary after it wrote, 0 to disable", func(c *Config) flag.Value { return (*durationValue)(&c.DB.ReadYourWrites) }},
+	{"MAX_BATCH_SIZE", "max-batch-size", "Maximum number of items of batch RPCs", func(c *Config) flag.Value { return (*intValue)(&c.API.MaxBatchSize) }},
+	{"IDEMPOTENCY_TTL", "idempotency-ttl", "How long responses are kept for Idempotency-Key replays", func(c *Config) flag.Value { return (*durationValue)(&c.API.IdempotencyTTL) }},
+	{"IDEMPOTENCY_LEASE", "idempotency-lease", "How long a running request holds its Idempotency-Key, freed when it expires", func(c *Config) flag.Value { return (*durationValue)(&c.API.IdempotencyLease) }},
+	{"SERVER_ADDRESS", "server-address", "The address to the gRPC server, in the gRPC standard naming format. " +
+		"See https://github.com/grpc/grpc/blob/master/doc/naming.md for more information.", func(c *Config) flag.Value { return (*stringValue)(&c.Gateway.ServerAddress) }},
+	{"GATEWAY_PORT", "gateway-port", "Port the standalone gateway listens on", func(c *Config) flag.Value { return (*intValue)(&c.Gateway.Port) }},
+	{"GATEWAY_METRICS_ADDR", "gateway-metrics-addr", "Address the standalone gateway serves /metrics on, disabled when empty", func(c *Config) flag.Value { return (*stringValue)(&c.Gateway.MetricsAddr) }},
+	{"SERVICE_NAME", "service-name", "Service name reported in traces", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.ServiceName) }},
+	{"TRACING_EXPORTER", "tracing-exporter", "Where spans are exported: otlp, stdout, or nowhere when empty", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.Exporter) }},
+	{"OTLP_ENDPOINT", "otlp-endpoint", "OpenTelemetry collector receiving spans over OTLP/gRPC", func(c *Config) flag.Value { return (*stringValue)(&c.Tracing.OTLPEndpoint) }},
+	{"OTLP_INSECURE", "otlp-insecure", "Send spans to the collector without TLS", func(c *Config) flag.Value { return (*boolValue)(&c.Tracing.OTLPInsecure) }},
+	{"TRACING_SAMPLE_RATIO", "tracing-sample-ratio", "Fraction of the traces started by the server that are recorded", func(c *Config) flag.Value { return (*floatValue)(&c.Tracing.SampleRatio) }},
+	{"LOG_LEVEL", "log-level", "Lowest level logged: debug, info, warn or error", func(c *Config) flag.Value { return (*stringValue)(&c.Log.Level) }},
+	{"LOG_SQL", "log-sql", "Log every SQL query, without its values", func(c *Config) flag.Value { return (*boolValue)(&c.Log.SQL) }},
+	{"RATE_LIMIT_RATE", "rate-limit-rate", "Requests per second each client may make to a method, unlimited when 0", func(c *Config) flag.Value { return (*floatValue)(&c.RateLimit.Rate) }},
+	{"RATE_LIMIT_BURST", "rate-limit-burst", "Requests each client may make at once to a method", func(c *Config) flag.Value { return (*intValue)(&c.RateLimit.Burst) }},
+	{"RATE_LIMIT_METHODS", "rate-limit-methods", "Comma separated method=rate:burst limits overriding the rate and burst, such as /event.EventService/AddEvent=1:5", func(c *Config) flag.Value { return (*methodLimitsValue)(&c.RateLimit.Methods) }},
+	{"RATE_LIMIT_API_KEYS", "rate-limit-api-keys", "Comma separated API keys identifying clients by their X-API-Key, other keys are ignored", func(c *Config) flag.Value { return (*stringsValue)(&c.RateLimit.APIKeys) }},
+	{"RATE_LIMIT_TRUSTED_PROXIES", "rate-limit-trusted-proxies", "Comma separated networks of the proxies whose X-Forwarded-For identifies clients, such as 10.0.0.0/8", func(c *Config) flag.Value { return (*stringsValue)(&c.RateLimit.TrustedProxies) }},
+	{"SEED_DIR", "seed-dir", "Directory holding a directory of fixture files per set", func(c *Config) flag.Value { return (*stringValue)(&c.Seed.Dir) }},
+	{"SEED_SETS", "seed-sets", "Comma separated fixture sets seeded on startup, such as demo", func(c *Config) flag.Value { return (*stringsValue)(&c.Seed.Sets) }},
+	{"CACHE_SIZE", "cache-size", "Number of users and events cached in memory for GetUser and GetEvent, disabled when 0", func(c *Config) flag.Value { return (*intValue)(&c.Cache.Size) }},
+	{"CACHE_TTL", "cache-ttl", "How long users and events are cached for", func(c *Config) flag.Value { return (*durationValue)(&c.Cache.TTL) }},
+}
+
+// Load builds the configuration from, by increasing precedence, the defaults,
+// the YAML file named by --config or $CONFIG_FILE, the environment (including
+// a .env file, if any) and the command line flags. Empty variables set the
+// options taking strings, such as $METRICS_ADDR disabling metrics, and are
+// ignored by the others. It also reports whether --print-config was given.
+func Load(name string, args []string) (cfg Config, printConfig bool, err error) {
+	// Variables already set in the environment take precedence over .env
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, false, fmt.Errorf("loading .env: %w", err)
+	}
+
+	cfg = Default()
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file")
+	fs.BoolVar(&printConfig, "print-config", false, "Print the configuration, with secrets redacted, and exit")
+	for _, o := range options {
+		fs.Var(o.value(&cfg), o.flag, fmt.Sprintf("%s ($%s)", o.usage, o.env))
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, false, err
+	}
+
+	// Flags were parsed into cfg to know the file to read: start over and
+	// apply them last.
+	set := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = f.Value.String() })
+	cfg = Default()
+
+	if *file != "" {
+		data, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return cfg, false, fmt.Errorf("reading config file: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+			return cfg, false, fmt.Errorf("parsing config file %s: %w", *file, err)
+		}
+	}
+
+	for _, o := range options {
+		if v, ok := os.LookupEnv(o.env); ok && (v != "" || takesStrings(o.value(&cfg))) {
+			if err := o.value(&cfg).Set(v); err != nil {
+				return cfg, false, fmt.Errorf("invalid $%s: %w", o.env, err)
+			}
+		}
+	}
+
+	for _, o := range options {
+		if v, ok := set[o.flag]; ok {
+			if err := o.value(&cfg).Set(v); err != nil {
+				return cfg, false, fmt.Errorf("invalid -%s: %w", o.flag, err)
+			}
+		}
+	}
+
+	return cfg, printConfig, cfg.Validate()
+}
+
+// Validate reports the first invalid setting.
+func (c Config) Validate() error {
+	switch {
+	case c.Server.Port < 1 || c.Server.Port > 65535:
+		return fmt.Errorf("invalid server port %d", c.Server.Port)
+	case c.Gateway.Port < 1 || c.Gateway.Port > 65535:
+		return fmt.Errorf("invalid gateway port %d", c.Gateway.Port)
+	case c.Server.ShutdownTimeout <= 0:
+		return errors.New("the shutdown timeout must be positive")
+	case c.Server.HealthCheckInterval <= 0:
+		return errors.New("the health check interval must be positive")
+	case (c.TLS.CertFile == "") != (c.TLS.KeyFile == ""):
+		return errors.New("both a TLS certificate and a key file are required")
+	case c.TLS.ClientAuth && c.TLS.CAFile == "":
+		return errors.New("a TLS CA file is required to verify client certificates")
+	case c.TLS.ReloadInterval <= 0:
+		return errors.New("the TLS reload interval must be positive")
+	case c.DB.Driver == "":
+		return errors.New("a database driver is required")
+	case !storage.ValidDriver(c.DB.Driver):
+		return fmt.Errorf("unknown database driver %q", c.DB.Driver)
+	case c.DB.MaxOpenConns < 0 || c.DB.MaxIdleConns < 0:
+		return errors.New("the maximum numbers of database connections cannot be negative")
+	case c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns:
+		return fmt.Errorf("the %d idle database connections exceed the maximum of %d open ones", c.DB.MaxIdleConns, c.DB.MaxOpenConns)
+	case c.DB.ConnMaxLifetime < 0:
+		return errors.New("the database connection lifetime cannot be negative")
+	case c.DB.ConnectTimeout < 0:
+		return errors.New("the database connect timeout cannot be negative")
+	case len(c.DB.Replicas) > 0 && c.DB.Driver != storage.PostgresDriver && c.DB.Driver != storage.MySQLDriver:
+		return fmt.Errorf("read replicas need a %s or %s database", storage.PostgresDriver, storage.MySQLDriver)
+	case c.DB.ReadYourWrites < 0:
+		return errors.New("the read your writes window cannot be negative")
+	case len(c.Seed.Sets) > 0 && c.Seed.Dir == "":
+		return errors.New("a fixtures directory is required to seed sets")
+	case c.Cache.Size < 0:
+		return errors.New("the cache size cannot be negative")
+	case c.Cache.Size > 0 && c.Cache.TTL <= 0:
+		return errors.New("the cache TTL must be positive")
+	case c.API.MaxBatchSize < 1:
+		return fmt.Errorf("invalid max batch size %d", c.API.MaxBatchSize)
+	case c.API.IdempotencyTTL <= 0:
+		return errors.New("the idempotency TTL must be positive")
+	case c.API.IdempotencyLease <= 0:
+		return errors.New("the idempotency lease must be positive")
+	case c.Tracing.Exporter != tracing.ExporterNone && c.Tracing.Exporter != tracing.ExporterStdout && c.Tracing.Exporter != tracing.ExporterOTLP:
+		return fmt.Errorf("unknown tracing exporter %q", c.Tracing.Exporter)
+	case c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1:
+		return fmt.Errorf("invalid tracing sample ratio %g", c.Tracing.SampleRatio)
+	case !logging.ValidLevel(c.Log.Level):
+		return fmt.Errorf("invalid log level %q", c.Log.Level)
+	}
+	for _, replica := range c.DB.Replicas {
+		if _, port, err := net.SplitHostPort(replica); err != nil || port == "" {
+			return fmt.Errorf("invalid read replica address %q, expected host:port", replica)
+		}
+	}
+	if err := validateLimit("", c.RateLimit.Rate, c.RateLimit.Burst); err != nil {
+		return err
+	}
+	for _, cidr := range c.RateLimit.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid trusted proxy network %q, expected a CIDR such as 10.0.0.0/8", cidr)
+		}
+	}
+	for method, l := range c.RateLimit.Methods {
+		if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+			return fmt.Errorf("invalid rate limited method %q, expected /package.Service/Method", method)
+		}
+		if err := validateLimit(method, l.Rate, l.Burst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLimit(method string, rate float64, burst int) error {
+	if method != "" {
+		method = " of " + method
+	}
+	switch {
+	case rate < 0:
+		return fmt.Errorf("invalid rate limit%s %g", method, rate)
+	case rate > 0 && burst < 1:
+		return fmt.Errorf("the rate limit burst%s must be at least 1", method)
+	}
+	return nil
+}
+
+// PrintYAML writes the configuration to w as YAML, with secrets redacted.
+func (c Config) PrintYAML(w io.Writer) error {
+	if c.DB.Password != "" {
+		c.DB.Password = redacted
+	}
+	if c.TLS.KeyFile != "" {
+		c.TLS.KeyFile = redacted
+	}
+	if len(c.RateLimit.APIKeys) > 0 {
+		c.RateLimit.APIKeys = []string{redacted}
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// takesStrings reports whether v is set from strings as they are, empty ones
+// included.
+func takesStrings(v flag.Value) bool {
+	switch v.(type) {
+	case *stringValue, *stringsValue:
+		return true
+	}
+	return false
+}
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+func (v *stringValue) String() string     { return string(*v) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v = intValue(i)
+	return nil
+}
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v = boolValue(b)
+	return nil
+}
+func (v *boolValue) String() string   { return strconv.FormatBool(bool(*v)) }
+func (v *boolValue) IsBoolFlag() bool { return true }
+
+type durationValue Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*v = floatValue(f)
+	return nil
+}
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+
+// stringsValue parses comma separated values.
+type stringsValue []string
+
+func (v *stringsValue) Set(s string) error {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	*v = values
+	return nil
+}
+func (v *stringsValue) String() string { return strings.Join(*v, ",") }
+
+// methodLimitsValue parses method=rate:burst pairs.
+type methodLimitsValue map[string]MethodLimit
+
+func (v *methodLimitsValue) Set(s string) error {
+	limits := make(map[string]MethodLimit)
+	for _, pair := range strings.Split(s, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		j := strings.LastIndex(pair, ":")
+		if i < 0 || j < i {
+			return fmt.Errorf("invalid method limit %q, expected method=rate:burst", pair)
+		}
+		rate, err := strconv.ParseFloat(pair[i+1:j], 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate of %q: %w", pair, err)
+		}
+		burst, err := strconv.Atoi(pair[j+1:])
+		if err != nil {
+			return fmt.Errorf("invalid burst of %q: %w", pair, err)
+		}
+		limits[pair[:i]] = MethodLimit{Rate: rate, Burst: burst}
+	}
+	*v = limits
+	return nil
+}
+func (v *methodLimitsValue) String() string {
+	pairs := make([]string, 0, len(*v))
+	for method, l := range *v {
+		pairs = append(pairs, fmt.Sprintf("%s=%g:%d", method, l.Rate, l.Burst))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
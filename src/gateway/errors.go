@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// problem is the JSON body of error responses, following RFC 7807
+// (https://tools.ietf.org/html/rfc7807) with the gRPC code and field
+// violations as extension members.
+type problem struct {
+	Type       string      `json:"type"`
+	Title      string      `json:"title"`
+	Status     int         `json:"status"`
+	Detail     string      `json:"detail,omitempty"`
+	Code       string      `json:"code"`
+	Violations []violation `json:"violations,omitempty"`
+}
+
+type violation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// problemErrorHandler renders gRPC errors, including routing errors raised by
+// the gateway itself, as application/problem+json responses.
+func problemErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	s := status.Convert(err)
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+
+	body := problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(httpStatus),
+		Status: httpStatus,
+		Detail: s.Message(),
+		Code:   s.Code().String(),
+	}
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, fv := range d.GetFieldViolations() {
+				body.Violations = append(body.Violations, violation{
+					Field:       fv.GetField(),
+					Description: fv.GetDescription(),
+				})
+			}
+		case *errdetails.RetryInfo:
+			if delay := d.GetRetryDelay(); delay != nil {
+				// Retry-After counts whole seconds
+				seconds := delay.GetSeconds()
+				if delay.GetNanos() > 0 {
+					seconds++
+				}
+				w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+			}
+		}
+	}
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for k, vs := range md.HeaderMD {
+			h, ok := outgoingHeaderMatcher(k)
+			if !ok {
+				continue
+			}
+			for _, v := range vs {
+				w.Header().Add(h, v)
+			}
+		}
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		zap.L().Info("Failed to write error response", zap.Error(err))
+	}
+}
@@ -2,22 +2,34 @@ package gateway
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
-	"io/ioutil"
 	"mime"
+	"net"
 	"net/http"
-	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rakyll/statik/fs"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 
-	"github.com/RemyRanger/taktyl_core_grpc/src/insecure"
+	"github.com/RemyRanger/taktyl_core_grpc/src/idempotency"
+	"github.com/RemyRanger/taktyl_core_grpc/src/identity"
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/metrics"
 	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
 	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tlsconfig"
 
 	// Static files
 	_ "github.com/RemyRanger/taktyl_core_grpc/statik"
@@ -37,62 +49,222 @@ func getOpenAPIHandler() http.Handler {
 	return http.FileServer(statikFS)
 }
 
-// Run runs the gRPC-Gateway, dialling the provided address.
-func Run(dialAddr string) error {
-	// Adds gRPC internal logs. This is quite verbose, so adjust as desired!
-	log := grpclog.NewLoggerV2(os.Stdout, ioutil.Discard, ioutil.Discard)
-	grpclog.SetLoggerV2(log)
+// incomingHeaderMatcher forwards the Idempotency-Key, X-Request-ID, X-API-Key
+// and X-Consistency headers to the gRPC server in addition to the headers
+// accepted by the default matcher. Clients may not set the forwarded certificate
+// themselves, see forwardClientCert.
+func incomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "Idempotency-Key") {
+		return idempotency.MetadataKey, true
+	}
+	if strings.EqualFold(key, logging.RequestIDHeader) {
+		return logging.RequestIDKey, true
+	}
+	if strings.EqualFold(key, "X-API-Key") {
+		return ratelimit.APIKeyKey, true
+	}
+	if strings.EqualFold(key, "X-Consistency") {
+		return server.ConsistencyKey, true
+	}
+	if strings.EqualFold(key, runtime.MetadataHeaderPrefix+identity.ForwardedCertKey) {
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
 
-	// Create a client connection to the gRPC Server we just started.
-	// This is where the gRPC-Gateway proxies the requests.
-	conn, err := grpc.DialContext(
-		context.Background(),
-		dialAddr,
-		//grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(insecure.CertPool, "")),
-		grpc.WithInsecure(),
-		grpc.WithBlock(),
+// outgoingHeaderMatcher prefixes the response header metadata like the
+// default matcher, except for the request ID which logging.RequestIDHandler
+// already returns in X-Request-ID.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == logging.RequestIDKey {
+		return "", false
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// forwardClientCert passes the verified certificate of the HTTP client on to
+// the gRPC server, which trusts it because the gateway dials with the
+// server's own certificate.
+func forwardClientCert(_ context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return metadata.Pairs(identity.ForwardedCertKey, string(r.TLS.VerifiedChains[0][0].Raw))
+}
+
+// Dial creates the client connection the gRPC-Gateway proxies requests
+// through. The connection uses TLS, verifying the server and authenticating
+// with the certificates of certs, unless certs is nil.
+func Dial(ctx context.Context, dialAddr string, certs *tlsconfig.Reloader, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	creds := grpc.WithInsecure()
+	if certs != nil {
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(certs.ClientConfig()))
+	}
+	opts = append([]grpc.DialOption{
+		creds,
+		// Propagate the trace context of HTTP requests to the gRPC server
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	}, opts...)
+	conn, err := grpc.DialContext(ctx, dialAddr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial server: %w", err)
+	}
+	return conn, nil
+}
+
+// New returns a handler serving the gRPC-Gateway on /api, proxying to conn,
+// the /healthz and /readyz probes, and the OpenAPI UI on every other path.
+func New(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	gwmux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(problemErrorHandler),
+		runtime.WithMarshalerOption(mimeEventStream, newSSEMarshaler()),
+		runtime.WithMetadata(forwardClientCert),
 	)
+	err := pbUser.RegisterUserServiceHandler(ctx, gwmux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+
+	err = pbEvent.RegisterEventServiceHandler(ctx, gwmux, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %w", err)
+	}
+
+	oa := metrics.InstrumentHandler("openapi", getOpenAPIHandler())
+	api := metrics.InstrumentHandler("api", otelhttp.NewHandler(logging.RequestIDHandler(streamMode(gwmux)), "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "HTTP " + r.Method
+		}),
+	))
+	health := metrics.InstrumentHandler("health", healthHandler(conn))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api"):
+			api.ServeHTTP(w, r)
+		case r.URL.Path == "/healthz", r.URL.Path == "/readyz":
+			health.ServeHTTP(w, r)
+		default:
+			oa.ServeHTTP(w, r)
+		}
+	}), nil
+}
+
+// Run runs the gRPC-Gateway on addr, dialling the provided address, until ctx
+// is done. It then drains in-flight requests for up to shutdownTimeout. certs
+// is used both to serve and to dial, over cleartext if nil.
+func Run(ctx context.Context, dialAddr, addr string, certs *tlsconfig.Reloader, shutdownTimeout time.Duration) error {
+	// Create a client connection to the gRPC Server.
+	// This is where the gRPC-Gateway proxies the requests.
+	conn, err := Dial(ctx, dialAddr, certs, grpc.WithBlock())
 	if err != nil {
-		return fmt.Errorf("failed to dial server: %w", err)
+		return err
 	}
+	defer conn.Close()
 
-	gwmux := runtime.NewServeMux()
-	err = pbUser.RegisterUserServiceHandler(context.Background(), gwmux, conn)
+	handler, err := New(context.Background(), conn)
 	if err != nil {
-		return fmt.Errorf("failed to register gateway: %w", err)
+		return err
 	}
 
-	err = pbEvent.RegisterEventServiceHandler(context.Background(), gwmux, conn)
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		return fmt.Errorf("failed to register gateway: %w", err)
+		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	oa := getOpenAPIHandler()
+	srv := NewServer(handler, certs)
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(lis) }()
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "11000"
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
 	}
-	gatewayAddr := "0.0.0.0:" + port
-	gwServer := &http.Server{
-		Addr: gatewayAddr,
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if strings.HasPrefix(r.URL.Path, "/api") {
-				gwmux.ServeHTTP(w, r)
-				return
-			}
-			oa.ServeHTTP(w, r)
-		}),
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// Server serves a handler over TLS, or over cleartext HTTP/1 and h2c, and
+// keeps track of in-flight requests to drain them on shutdown.
+type Server struct {
+	srv    *http.Server
+	tls    bool
+	active int64
+}
+
+// NewServer returns a Server serving handler over TLS with the certificates
+// of certs, or over cleartext HTTP/1 and h2c if certs is nil.
+func NewServer(handler http.Handler, certs *tlsconfig.Reloader) *Server {
+	s := &Server{
+		srv: &http.Server{},
+		tls: certs != nil,
+	}
+	// Requests are counted here rather than relying on http.Server, which
+	// forgets about the hijacked connections of h2c and WebSockets.
+	counted := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&s.active, 1)
+		defer atomic.AddInt64(&s.active, -1)
+		handler.ServeHTTP(w, r)
+	})
+
+	if certs == nil {
+		// h2c lets native gRPC clients speak HTTP/2 without TLS. Configuring
+		// the HTTP/2 server sends GOAWAY to its connections on Shutdown.
+		h2s := &http2.Server{}
+		s.srv.Handler = h2c.NewHandler(counted, h2s)
+		if err := http2.ConfigureServer(s.srv, h2s); err != nil {
+			// Panic since this is a programming error.
+			panic("configuring h2c server: " + err.Error())
+		}
+		return s
+	}
+
+	s.srv.Handler = counted
+	s.srv.TLSConfig = certs.ServerConfig()
+	return s
+}
+
+// Serve serves on lis until Shutdown is called, in which case it returns nil.
+func (s *Server) Serve(lis net.Listener) error {
+	var err error
+	if s.tls {
+		zap.L().Info("Serving on https://" + lis.Addr().String())
+		// Empty parameters mean use the TLS Config specified with the server.
+		err = s.srv.ServeTLS(lis, "", "")
+	} else {
+		zap.L().Info("Serving on http://" + lis.Addr().String())
+		err = s.srv.Serve(lis)
 	}
-	// Empty parameters mean use the TLS Config specified with the server.
-	if strings.ToLower(os.Getenv("SERVE_HTTP")) == "true" {
-		log.Info("Serving gRPC-Gateway and OpenAPI Documentation on http://", gatewayAddr)
-		return fmt.Errorf("serving gRPC-Gateway server: %w", gwServer.ListenAndServe())
+	if err == http.ErrServerClosed {
+		return nil
 	}
+	return fmt.Errorf("serving gRPC-Gateway server: %w", err)
+}
 
-	gwServer.TLSConfig = &tls.Config{
-		Certificates: []tls.Certificate{insecure.Cert},
+// Shutdown stops accepting connections and waits for in-flight requests to
+// complete. Once ctx is done, the remaining connections are closed and the
+// context error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.srv.Shutdown(ctx)
+	if err == nil {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for atomic.LoadInt64(&s.active) > 0 && err == nil {
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	}
+	if err != nil {
+		s.srv.Close()
 	}
-	log.Info("Serving gRPC-Gateway and OpenAPI Documentation on https://", gatewayAddr)
-	return fmt.Errorf("serving gRPC-Gateway server: %w", gwServer.ListenAndServeTLS("", ""))
+	return err
 }
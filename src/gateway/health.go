@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readyTimeout bounds the health check made by /readyz.
+const readyTimeout = 2 * time.Second
+
+// healthHandler serves /healthz, which succeeds as long as the process
+// serves HTTP, and /readyz, which succeeds when the gRPC server behind conn
+// reports SERVING through the grpc.health.v1 service.
+func healthHandler(conn *grpc.ClientConn) http.Handler {
+	client := healthpb.NewHealthClient(conn)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, http.StatusOK, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			writeHealth(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			writeHealth(w, http.StatusServiceUnavailable, resp.Status.String())
+			return
+		}
+		writeHealth(w, http.StatusOK, resp.Status.String())
+	})
+	return mux
+}
+
+func writeHealth(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	w.Write([]byte(msg + "\n"))
+}
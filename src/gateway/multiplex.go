@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+// Multiplex routes native gRPC and gRPC-Web requests to grpcServer and every
+// other request to handler, so that all of them can be served on one port.
+func Multiplex(grpcServer *grpc.Server, handler http.Handler) http.Handler {
+	grpcWeb := grpcweb.WrapServer(grpcServer)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case grpcWeb.IsGrpcWebRequest(r), grpcWeb.IsAcceptableGrpcCorsRequest(r):
+			grpcWeb.ServeHTTP(w, r)
+		case r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc"):
+			grpcServer.ServeHTTP(w, r)
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// mimeEventStream is the Accept value selecting Server-Sent Events.
+	mimeEventStream = "text/event-stream"
+	// streamParam is the query parameter selecting a streaming mode for
+	// clients that cannot set headers, e.g. ?stream=sse.
+	streamParam = "stream"
+)
+
+// sseMarshaler renders gateway responses as Server-Sent Events
+// (https://html.spec.whatwg.org/multipage/server-sent-events.html). Every
+// message of a server stream becomes one event and stream errors are sent as
+// an "error" event. Requests are decoded as JSON.
+type sseMarshaler struct {
+	runtime.Marshaler
+}
+
+func newSSEMarshaler() *sseMarshaler {
+	return &sseMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		},
+	}
+}
+
+// ContentType implements runtime.Marshaler.
+func (m *sseMarshaler) ContentType(_ interface{}) string {
+	return mimeEventStream
+}
+
+// Marshal implements runtime.Marshaler. The returned event already ends with
+// the blank line terminating it, since the gateway writes stream errors
+// without a delimiter.
+func (m *sseMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.Marshaler.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if chunk, ok := v.(map[string]proto.Message); ok {
+		if _, ok := chunk["error"]; ok {
+			buf.WriteString("event: error\n")
+		}
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// Delimiter implements runtime.Delimited: events carry their own terminator.
+func (m *sseMarshaler) Delimiter() []byte {
+	return nil
+}
+
+// streamMode lets clients select Server-Sent Events with ?stream=sse when
+// they cannot set the Accept header, and serves WebSocket upgrades with
+// websocketProxy.
+func streamMode(h http.Handler) http.Handler {
+	ws := websocketProxy(h)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		if strings.EqualFold(r.URL.Query().Get(streamParam), "sse") {
+			r.Header.Set("Accept", mimeEventStream)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// websocketProxy serves a gateway route over a WebSocket: the upgrade request
+// is replayed against h as a plain GET and every message of the resulting
+// stream is sent as one text frame. The socket is closed when the stream
+// ends, and closing it from the client cancels the RPC.
+func websocketProxy(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// Upgrade already replied to the client.
+			zap.L().Info("Failed to upgrade websocket", zap.Error(err))
+			return
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		// Server streams take no client messages: only watch for the close.
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		req := r.Clone(ctx)
+		req.Method = http.MethodGet
+		for _, header := range []string{"Connection", "Upgrade", "Sec-Websocket-Key", "Sec-Websocket-Version", "Sec-Websocket-Extensions", "Sec-Websocket-Protocol"} {
+			req.Header.Del(header)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		ww := &websocketResponseWriter{conn: conn, header: http.Header{}}
+		h.ServeHTTP(ww, req)
+		ww.Flush()
+
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		if ww.status >= http.StatusBadRequest {
+			closeMsg = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, http.StatusText(ww.status))
+		}
+		if err := conn.WriteMessage(websocket.CloseMessage, closeMsg); err != nil {
+			zap.L().Info("Failed to close websocket", zap.Error(err))
+		}
+	})
+}
+
+// websocketResponseWriter sends what the gateway writes between two flushes
+// as one text message, without the trailing stream delimiter.
+type websocketResponseWriter struct {
+	conn   *websocket.Conn
+	header http.Header
+	status int
+	buf    bytes.Buffer
+	err    error
+}
+
+func (w *websocketResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *websocketResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *websocketResponseWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.buf.Write(p)
+}
+
+func (w *websocketResponseWriter) Flush() {
+	msg := bytes.TrimRight(w.buf.Bytes(), "\n")
+	if w.err != nil || len(msg) == 0 {
+		return
+	}
+	w.err = w.conn.WriteMessage(websocket.TextMessage, msg)
+	w.buf.Reset()
+}
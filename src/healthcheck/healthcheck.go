@@ -0,0 +1,58 @@
+package healthcheck
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger checks a dependency is reachable, as *sql.DB does.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Monitor pings db every interval until ctx is done and reports the server,
+// and each of services, as SERVING while pings succeed and NOT_SERVING
+// otherwise. The first ping is immediate.
+func Monitor(ctx context.Context, srv *health.Server, db Pinger, interval time.Duration, services ...string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	status := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		err := db.PingContext(pingCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		next := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if next != status {
+			if err != nil {
+				zap.L().Warn("Database ping failed, reporting NOT_SERVING", zap.Error(err))
+			}
+			setStatus(srv, next, services)
+			status = next
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func setStatus(srv *health.Server, status healthpb.HealthCheckResponse_ServingStatus, services []string) {
+	srv.SetServingStatus("", status)
+	for _, service := range services {
+		srv.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,94 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+// GormStore keeps idempotency records in the idempotency_keys table.
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by db.
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+// transaction runs fn in a transaction bound to ctx, gorm queries taking no
+// context of their own.
+func (s *GormStore) transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	tx := s.db.BeginTx(ctx, nil)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// Reserve implements Store.
+func (s *GormStore) Reserve(ctx context.Context, rec *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	// Free rec.Key if its record expired, the other expired ones wait for Sweep
+	err := s.transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Where("idempotency_key = ? AND expires_at < ?", rec.Key, time.Now()).Delete(&models.IdempotencyKey{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	createErr := s.transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Create(rec).Error
+	})
+	if createErr == nil {
+		return nil, nil
+	}
+
+	// The insert most likely hit the primary key: report the existing record.
+	existing := models.IdempotencyKey{}
+	err = s.transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Where("idempotency_key = ?", rec.Key).Take(&existing).Error
+	})
+	if err != nil {
+		return nil, createErr
+	}
+	return &existing, nil
+}
+
+// Complete implements Store.
+func (s *GormStore) Complete(ctx context.Context, key, token, responseType string, response []byte, expiresAt time.Time) error {
+	return s.transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Model(&models.IdempotencyKey{}).Where("idempotency_key = ? AND token = ?", key, token).Updates(
+			map[string]interface{}{
+				"response_type": responseType,
+				"response":      response,
+				"completed":     true,
+				"expires_at":    expiresAt,
+			},
+		).Error
+	})
+}
+
+// Release implements Store.
+func (s *GormStore) Release(ctx context.Context, key, token string) error {
+	return s.transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Where("idempotency_key = ? AND token = ?", key, token).Delete(&models.IdempotencyKey{}).Error
+	})
+}
+
+// Sweep implements Store.
+func (s *GormStore) Sweep(ctx context.Context) (int64, error) {
+	var n int64
+	err := s.transaction(ctx, func(tx *gorm.DB) error {
+		result := tx.Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+		n = result.RowsAffected
+		return result.Error
+	})
+	return n, err
+}
@@ -0,0 +1,223 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+const (
+	// MetadataKey is the incoming metadata key carrying the client supplied key.
+	// The gateway maps the Idempotency-Key HTTP header onto it.
+	MetadataKey = "idempotency-key"
+	// ReplayedKey is set in the response header metadata when a stored response is replayed.
+	ReplayedKey = "idempotent-replayed"
+
+	maxKeyLength = 255
+)
+
+// SweepInterval is how often Sweep deletes the expired records by default.
+const SweepInterval = time.Minute
+
+// Store persists idempotency records.
+type Store interface {
+	// Reserve claims rec.Key for a new request until rec.ExpiresAt. If an
+	// unexpired record already exists for that key it is returned instead and
+	// nothing is written; an expired one is replaced.
+	//
+	// rec.Token identifies the reservation: Complete and Release leave the
+	// record alone once another reservation replaced it, the lease having
+	// expired while the request ran.
+	Reserve(ctx context.Context, rec *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	// Complete stores the response of the request holding key under token,
+	// kept until expiresAt.
+	Complete(ctx context.Context, key, token, responseType string, response []byte, expiresAt time.Time) error
+	// Release drops the reservation on key held under token so the request
+	// can be retried.
+	Release(ctx context.Context, key, token string) error
+	// Sweep deletes the expired records and returns how many it deleted.
+	Sweep(ctx context.Context) (int64, error)
+}
+
+// Sweep deletes the expired records of store every interval until ctx is
+// done.
+func Sweep(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := store.Sweep(ctx); err != nil && ctx.Err() == nil {
+			zap.L().Warn("Cannot delete the expired idempotency records", zap.Error(err))
+		}
+	}
+}
+
+// UnaryServerInterceptor makes the given full method names idempotent for
+// callers sending an Idempotency-Key. The first successful response is kept
+// for ttl and replayed to retries carrying the same key and payload, while
+// reusing a key with a different payload is rejected. Failed requests are not
+// recorded, so they can be retried with the same key.
+//
+// A request holds its key for lease while it runs, retries failing with
+// Aborted meanwhile. Should its response not be stored, the server crashing
+// or the store failing, the key is free again once the lease expires. A
+// request outliving its lease leaves the key to the retry that took it over.
+//
+// Keys are scoped to the client client identifies, so that clients choosing
+// the same key do not collide.
+func UnaryServerInterceptor(store Store, ttl, lease time.Duration, client func(ctx context.Context) string, methods ...string) grpc.UnaryServerInterceptor {
+	enabled := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		enabled[m] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !enabled[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		key := keyFromContext(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > maxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Idempotency-Key must be at most %d characters", maxKeyLength)
+		}
+
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		fingerprint, err := Fingerprint(info.FullMethod, msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error while fingerprinting request: %v", err)
+		}
+		key = scopedKey(client(ctx), key)
+		token, err := newToken()
+		if err != nil {
+			logging.FromContext(ctx).Error("Cannot generate an idempotency token", zap.Error(err))
+			return nil, status.Error(codes.Internal, "Error while reserving idempotency key")
+		}
+
+		existing, err := store.Reserve(ctx, &models.IdempotencyKey{
+			Key:         key,
+			Token:       token,
+			Method:      info.FullMethod,
+			Fingerprint: fingerprint,
+			CreatedAt:   time.Now(),
+			ExpiresAt:   time.Now().Add(lease),
+		})
+		if err != nil {
+			logging.FromContext(ctx).Error("Cannot reserve the idempotency key", zap.Error(err))
+			return nil, status.Error(codes.Internal, "Error while reserving idempotency key")
+		}
+		if existing != nil {
+			return replay(ctx, existing, info.FullMethod, fingerprint)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if releaseErr := store.Release(ctx, key, token); releaseErr != nil {
+				logging.FromContext(ctx).Error("Cannot release the idempotency key, it is held until its lease expires", zap.Error(releaseErr))
+			}
+			return nil, err
+		}
+
+		// The request succeeded whether or not its response can be stored
+		respMsg, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+		data, err := proto.Marshal(respMsg)
+		if err == nil {
+			err = store.Complete(ctx, key, token, string(respMsg.ProtoReflect().Descriptor().FullName()), data, time.Now().Add(ttl))
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Cannot store the idempotent response, retries will run again once the lease expires", zap.Error(err))
+		}
+		return resp, nil
+	}
+}
+
+// Fingerprint hashes the method name together with the deterministic wire
+// encoding of the request.
+func Fingerprint(method string, req proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newToken returns a random token identifying a reservation.
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// scopedKey returns the key of a client under which its record is stored.
+func scopedKey(client, key string) string {
+	h := sha256.New()
+	h.Write([]byte(client))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replay(ctx context.Context, rec *models.IdempotencyKey, method, fingerprint string) (interface{}, error) {
+	if rec.Method != method || rec.Fingerprint != fingerprint {
+		return nil, status.Error(codes.InvalidArgument, "Idempotency-Key was already used with a different request")
+	}
+	if !rec.Completed {
+		return nil, status.Error(codes.Aborted, "A request with this Idempotency-Key is still in progress")
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(rec.ResponseType))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while replaying idempotent response: %v", err)
+	}
+	resp := mt.New().Interface()
+	if err := proto.Unmarshal(rec.Response, resp); err != nil {
+		return nil, status.Errorf(codes.Internal, "Error while replaying idempotent response: %v", err)
+	}
+	// Best effort: the header only informs the client, the replay is valid without it.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ReplayedKey, "true"))
+	return resp, nil
+}
+
+func keyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
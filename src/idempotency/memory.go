@@ -0,0 +1,79 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+// MemoryStore keeps idempotency records in process memory. Records are lost
+// on restart and are not shared between instances.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyKey
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]models.IdempotencyKey),
+	}
+}
+
+// Reserve implements Store.
+func (s *MemoryStore) Reserve(ctx context.Context, rec *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[rec.Key]; ok && !existing.ExpiresAt.Before(time.Now()) {
+		return &existing, nil
+	}
+	s.records[rec.Key] = *rec
+	return nil, nil
+}
+
+// Complete implements Store.
+func (s *MemoryStore) Complete(ctx context.Context, key, token, responseType string, response []byte, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[key]
+	if !ok || rec.Token != token {
+		return nil
+	}
+	rec.ResponseType = responseType
+	rec.Response = append([]byte(nil), response...)
+	rec.Completed = true
+	rec.ExpiresAt = expiresAt
+	s.records[key] = rec
+	return nil
+}
+
+// Release implements Store.
+func (s *MemoryStore) Release(ctx context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.records[key]; ok && rec.Token == token {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// Sweep implements Store.
+func (s *MemoryStore) Sweep(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	now := time.Now()
+	for key, r := range s.records {
+		if r.ExpiresAt.Before(now) {
+			delete(s.records, key)
+			n++
+		}
+	}
+	return n, nil
+}
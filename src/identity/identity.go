@@ -0,0 +1,118 @@
+package identity
+
+import (
+	"context"
+	"crypto/x509"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ForwardedCertKey is the metadata key a trusted proxy, such as the gateway,
+// sets to the DER encoded certificate its own client authenticated with.
+const ForwardedCertKey = "x-forwarded-client-cert-bin"
+
+// Identity describes the client certificate a request was authenticated with.
+type Identity struct {
+	Subject     string
+	CommonName  string
+	DNSNames    []string
+	URIs        []string
+	Certificate *x509.Certificate
+}
+
+type contextKey struct{}
+
+// FromCertificate returns the identity carried by cert.
+func FromCertificate(cert *x509.Certificate) *Identity {
+	id := &Identity{
+		Subject:     cert.Subject.String(),
+		CommonName:  cert.Subject.CommonName,
+		DNSNames:    cert.DNSNames,
+		Certificate: cert,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+	}
+	return id
+}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity of the client of the request ctx belongs
+// to, if it presented a verified certificate.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(*Identity)
+	return id, ok
+}
+
+// UnaryServerInterceptor stores the identity of verified client certificates
+// in the request context. Requests coming from a peer for which trustedProxy
+// returns true are attributed to the certificate it forwarded instead.
+func UnaryServerInterceptor(trustedProxy func(*x509.Certificate) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withIdentity(ctx, trustedProxy), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(trustedProxy func(*x509.Certificate) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &identityStream{ServerStream: ss, ctx: withIdentity(ss.Context(), trustedProxy)})
+	}
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func withIdentity(ctx context.Context, trustedProxy func(*x509.Certificate) bool) context.Context {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return ctx
+	}
+	if trustedProxy != nil && trustedProxy(cert) {
+		// The proxy verified its client before forwarding the certificate.
+		cert = forwardedCertificate(ctx)
+		if cert == nil {
+			return ctx
+		}
+	}
+	return NewContext(ctx, FromCertificate(cert))
+}
+
+// peerCertificate returns the verified leaf certificate of the peer, if any.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+func forwardedCertificate(ctx context.Context) *x509.Certificate {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(ForwardedCertKey)
+	if len(values) == 0 {
+		return nil
+	}
+	cert, err := x509.ParseCertificate([]byte(values[0]))
+	if err != nil {
+		return nil
+	}
+	return cert
+}
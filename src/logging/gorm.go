@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+)
+
+const (
+	contextKeyGorm = "logging:context"
+	startKey       = "logging:start"
+)
+
+// WithContext returns db carrying ctx, so that the queries made through it
+// are logged with the fields of the logger of ctx.
+func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Set(contextKeyGorm, ctx)
+}
+
+// GormLogger returns a gorm logger writing to the global logger, to replace
+// gorm's own which prints the values bound to statements. Its other messages,
+// such as callback registrations, are logged at debug level.
+func GormLogger() interface{ Print(v ...interface{}) } {
+	return gormLogger{}
+}
+
+type gormLogger struct{}
+
+// Print receives the level followed, for "sql", "log" and "error", by the
+// caller and either the duration, statement, values and affected rows, or
+// the message.
+func (gormLogger) Print(v ...interface{}) {
+	if len(v) < 2 {
+		return
+	}
+	l := zap.L().With(zap.String("system", "gorm"))
+	switch v[0] {
+	case "sql":
+		if len(v) < 6 {
+			return
+		}
+		l.Info("Query", zap.Any("db.statement", v[3]), zap.Any("db.rows_affected", v[5]), zap.Any("duration", v[2]), zap.Any("source", v[1]))
+	case "error":
+		l.Error(fmt.Sprint(v[2:]...), zap.Any("source", v[1]))
+	case "log":
+		l.Info(fmt.Sprint(v[2:]...), zap.Any("source", v[1]))
+	default:
+		l.Debug(fmt.Sprint(v[1:]...))
+	}
+}
+
+// InstrumentGorm logs every operation made through db, with its duration and
+// affected rows. Statements are logged with their
+// placeholders: the values bound to them are never logged, they may hold
+// passwords or personal data.
+func InstrumentGorm(db *gorm.DB) {
+	start := func(scope *gorm.Scope) {
+		scope.InstanceSet(startKey, time.Now())
+	}
+	end := func(scope *gorm.Scope) {
+		v, ok := scope.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		l := zap.L()
+		if ctx, ok := scope.Get(contextKeyGorm); ok {
+			l = FromContext(ctx.(context.Context))
+		}
+		fields := []zap.Field{
+			zap.String("db.statement", scope.SQL),
+			zap.Int("db.vars", len(scope.SQLVars)),
+			zap.Int64("db.rows_affected", scope.DB().RowsAffected),
+			zap.Duration("duration", time.Since(v.(time.Time))),
+		}
+		if err := scope.DB().Error; err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+		l.Info("Query", fields...)
+	}
+
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:begin_transaction").Register("logging:start", start)
+	callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("logging:end", end)
+	callbacks.Update().Before("gorm:begin_transaction").Register("logging:start", start)
+	callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("logging:end", end)
+	callbacks.Delete().Before("gorm:begin_transaction").Register("logging:start", start)
+	callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("logging:end", end)
+	callbacks.Query().Before("gorm:query").Register("logging:start", start)
+	callbacks.Query().After("gorm:after_query").Register("logging:end", end)
+	callbacks.RowQuery().Before("gorm:row_query").Register("logging:start", start)
+	callbacks.RowQuery().After("gorm:row_query").Register("logging:end", end)
+}
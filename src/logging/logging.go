@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/grpclog"
+)
+
+type contextKey struct{}
+
+// New returns a logger writing JSON lines to stdout from level on, one of
+// debug, info, warn or error.
+func New(level string) (*zap.Logger, error) {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(lvl)
+	cfg.EncoderConfig.TimeKey = "time"
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	cfg.OutputPaths = []string{"stdout"}
+	cfg.Sampling = nil
+	return cfg.Build()
+}
+
+// ValidLevel reports whether New accepts level.
+func ValidLevel(level string) bool {
+	var lvl zapcore.Level
+	return lvl.UnmarshalText([]byte(level)) == nil
+}
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger of ctx, holding the fields of the request
+// being served, or the global logger if ctx carries none.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+// ReplaceGlobals makes l the global logger, of both zap and grpclog. gRPC
+// internal logs are quite verbose, so its infos are logged at debug level.
+func ReplaceGlobals(l *zap.Logger) {
+	zap.ReplaceGlobals(l)
+	grpclog.SetLoggerV2(&grpcLogger{l: l.WithOptions(zap.AddCallerSkip(2)).Sugar().With("system", "grpc")})
+}
+
+// grpcLogger implements grpclog.LoggerV2.
+type grpcLogger struct {
+	l *zap.SugaredLogger
+}
+
+func (g *grpcLogger) Info(args ...interface{})                    { g.l.Debug(args...) }
+func (g *grpcLogger) Infoln(args ...interface{})                  { g.l.Debug(sprintln(args)) }
+func (g *grpcLogger) Infof(format string, args ...interface{})    { g.l.Debugf(format, args...) }
+func (g *grpcLogger) Warning(args ...interface{})                 { g.l.Warn(args...) }
+func (g *grpcLogger) Warningln(args ...interface{})               { g.l.Warn(sprintln(args)) }
+func (g *grpcLogger) Warningf(format string, args ...interface{}) { g.l.Warnf(format, args...) }
+func (g *grpcLogger) Error(args ...interface{})                   { g.l.Error(args...) }
+func (g *grpcLogger) Errorln(args ...interface{})                 { g.l.Error(sprintln(args)) }
+func (g *grpcLogger) Errorf(format string, args ...interface{})   { g.l.Errorf(format, args...) }
+func (g *grpcLogger) Fatal(args ...interface{})                   { g.l.Fatal(args...) }
+func (g *grpcLogger) Fatalln(args ...interface{})                 { g.l.Fatal(sprintln(args)) }
+func (g *grpcLogger) Fatalf(format string, args ...interface{})   { g.l.Fatalf(format, args...) }
+
+// V reports whether verbosity level l is enabled, gRPC only checks it
+// before logging details at debug level.
+func (g *grpcLogger) V(l int) bool {
+	return l <= 0 || g.l.Desugar().Core().Enabled(zapcore.DebugLevel)
+}
+
+func sprintln(args []interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
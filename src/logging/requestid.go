@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDHeader is the HTTP header identifying a request, kept when the
+	// client sets it and generated by the gateway otherwise.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the metadata key carrying the request ID, both in the
+	// incoming metadata and in the response header.
+	RequestIDKey = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestIDHandler sets the X-Request-ID header of the requests served by h,
+// so that the gateway forwards it to the gRPC server, and echoes it in the
+// response.
+func RequestIDHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+			r.Header.Set(RequestIDHeader, id)
+		}
+		w.Header().Set(RequestIDHeader, id)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// UnaryServerInterceptor gives each RPC a logger with its request ID and
+// method, see FromContext, and logs the RPC once it completes. The request ID
+// is taken from the incoming metadata, or generated, and sent back in the
+// response header.
+func UnaryServerInterceptor(l *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, rl := requestLogger(ctx, l, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, RequestIDFromContext(ctx))); err != nil {
+			rl.Warn("Failed to send the request ID", zap.Error(err))
+		}
+
+		resp, err := handler(ctx, req)
+		logCompleted(rl, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the stream counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(l *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, rl := requestLogger(ss.Context(), l, info.FullMethod)
+		if err := ss.SetHeader(metadata.Pairs(RequestIDKey, RequestIDFromContext(ctx))); err != nil {
+			rl.Warn("Failed to send the request ID", zap.Error(err))
+		}
+
+		err := handler(srv, &loggingStream{ServerStream: ss, ctx: ctx})
+		logCompleted(rl, start, err)
+		return err
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request being served, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func requestLogger(ctx context.Context, l *zap.Logger, method string) (context.Context, *zap.Logger) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(RequestIDKey); len(v) > 0 && validRequestID(v[0]) {
+			id = v[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+
+	fields := []zap.Field{zap.String("request_id", id), zap.String("grpc.method", method)}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID.String()))
+	}
+	rl := l.With(fields...)
+
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return NewContext(ctx, rl), rl
+}
+
+func logCompleted(l *zap.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{zap.String("grpc.code", code.String()), zap.Duration("duration", time.Since(start))}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	if ce := l.Check(levelFor(code), "Finished call"); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+// levelFor logs client errors as infos and server errors as errors.
+func levelFor(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return zapcore.ErrorLevel
+	case codes.DeadlineExceeded, codes.Unavailable:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// validRequestID rejects client supplied IDs that would bloat or garble logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+// loggingStream carries the context holding the request logger.
+type loggingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "lookups_total",
+	Help:      "Records looked up in the cache, by table and result: hit, miss, or bypass for the reads going to the primary database.",
+}, []string{"table", "result"})
+
+func init() {
+	prometheus.MustRegister(cacheLookups)
+}
+
+// ObserveCache counts a lookup of the records of table in the cache, to be set
+// as the Observe function of storage.Cached.
+func ObserveCache(table, result string) {
+	cacheLookups.WithLabelValues(table, result).Inc()
+}
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const startKey = "metrics:start"
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Latency of the database operations made through gorm, by database, operation and table.",
+		Buckets:   latencyBuckets,
+	}, []string{"db", "operation", "table"})
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "Database operations made through gorm that failed, not found records excluded.",
+	}, []string{"db", "operation", "table"})
+)
+
+func init() {
+	prometheus.MustRegister(dbQueryDuration, dbQueryErrors)
+}
+
+// InstrumentGorm times the operations made through db and exports the
+// statistics of its connection pool, both labelled with name, such as
+// primary. Call it once per database, on its root connection.
+func InstrumentGorm(db *gorm.DB, name string) {
+	start := func(scope *gorm.Scope) {
+		scope.InstanceSet(startKey, time.Now())
+	}
+	observe := func(operation string) func(scope *gorm.Scope) {
+		return func(scope *gorm.Scope) {
+			v, ok := scope.InstanceGet(startKey)
+			if !ok {
+				return
+			}
+			table := scope.TableName()
+			dbQueryDuration.WithLabelValues(name, operation, table).Observe(since(v.(time.Time)))
+			if scope.HasError() && !gorm.IsRecordNotFoundError(scope.DB().Error) {
+				dbQueryErrors.WithLabelValues(name, operation, table).Inc()
+			}
+		}
+	}
+
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:begin_transaction").Register("metrics:start", start)
+	callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("metrics:observe", observe("create"))
+	callbacks.Update().Before("gorm:begin_transaction").Register("metrics:start", start)
+	callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("metrics:observe", observe("update"))
+	callbacks.Delete().Before("gorm:begin_transaction").Register("metrics:start", start)
+	callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("metrics:observe", observe("delete"))
+	callbacks.Query().Before("gorm:query").Register("metrics:start", start)
+	callbacks.Query().After("gorm:after_query").Register("metrics:observe", observe("query"))
+	callbacks.RowQuery().Before("gorm:row_query").Register("metrics:start", start)
+	callbacks.RowQuery().After("gorm:row_query").Register("metrics:observe", observe("row_query"))
+
+	prometheus.MustRegister(newDBStatsCollector(db.DB(), name))
+}
+
+// dbStatsCollector exports the statistics of a connection pool.
+type dbStatsCollector struct {
+	db *sql.DB
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+func newDBStatsCollector(db *sql.DB, name string) *dbStatsCollector {
+	// The pools of the primary and of the replicas are told apart by label
+	labels := prometheus.Labels{"db": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", metric), help, nil, labels)
+	}
+	return &dbStatsCollector{
+		db:           db,
+		maxOpen:      desc("max_open_connections", "Maximum number of open connections, 0 for unlimited."),
+		open:         desc("open_connections", "Established connections, in use or idle."),
+		inUse:        desc("in_use_connections", "Connections in use."),
+		idle:         desc("idle_connections", "Idle connections."),
+		waitCount:    desc("wait_count_total", "Connections waited for."),
+		waitDuration: desc("wait_duration_seconds_total", "Time spent waiting for a connection."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+}
+
+// Collect implements prometheus.Collector.
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
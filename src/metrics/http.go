@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests served by the gateway, by handler, method and status code.",
+	}, []string{"handler", "method", "code"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of the HTTP requests served by the gateway, streams included.",
+		Buckets:   latencyBuckets,
+	}, []string{"handler", "method"})
+	httpInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests being served by the gateway.",
+	}, []string{"handler"})
+)
+
+func init() {
+	prometheus.MustRegister(httpRequests, httpDuration, httpInFlight)
+}
+
+// InstrumentHandler records the requests served by h under the handler
+// label name. Handlers are named after the routes they serve rather than the
+// request path, to keep the number of series bounded.
+func InstrumentHandler(name string, h http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": name}
+	return promhttp.InstrumentHandlerInFlight(httpInFlight.With(labels),
+		promhttp.InstrumentHandlerDuration(httpDuration.MustCurryWith(labels),
+			promhttp.InstrumentHandlerCounter(httpRequests.MustCurryWith(labels), h)))
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+const namespace = "taktyl"
+
+// latencyBuckets are the histogram buckets, in seconds, of every latency metric.
+var latencyBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+func init() {
+	// Per method latencies in addition to the request and status code counts
+	grpc_prometheus.EnableHandlingTimeHistogram(grpc_prometheus.WithHistogramBuckets(latencyBuckets))
+}
+
+// UnaryServerInterceptor counts unary RPCs by method and status code and
+// observes their latency.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return grpc_prometheus.UnaryServerInterceptor
+}
+
+// StreamServerInterceptor counts stream RPCs and their messages by method
+// and status code and observes their duration.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return grpc_prometheus.StreamServerInterceptor
+}
+
+// RegisterServer initializes the RPC metrics of every method of s to zero,
+// so that they are exported before being called. Call it once the services
+// are registered.
+func RegisterServer(s *grpc.Server) {
+	grpc_prometheus.Register(s)
+}
+
+// Server serves the metrics on /metrics of a listener separate from the API,
+// so that they are not exposed to clients.
+type Server struct {
+	srv *http.Server
+}
+
+// NewServer returns a Server exposing the metrics of the default registry.
+func NewServer() *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &Server{srv: &http.Server{Handler: mux}}
+}
+
+// Serve serves on lis until Shutdown is called, in which case it returns nil.
+func (s *Server) Serve(lis net.Listener) error {
+	zap.L().Info("Serving metrics on http://" + lis.Addr().String() + "/metrics")
+	if err := s.srv.Serve(lis); err != http.ErrServerClosed {
+		return fmt.Errorf("serving metrics: %w", err)
+	}
+	return nil
+}
+
+// Shutdown stops the server, waiting for scrapes in progress until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+func since(start time.Time) float64 {
+	return time.Since(start).Seconds()
+}
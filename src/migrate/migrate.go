@@ -0,0 +1,310 @@
+// Package migrate versions the database schema with the SQL scripts of
+// scripts/<driver>, embedded in the binary by statik (see the Makefile). Each
+// version has a <version>_<name>.up.sql script applying it and a
+// <version>_<name>.down.sql script reverting it; applied versions are
+// recorded in the schema_migrations table.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/rakyll/statik/fs"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate/migrations"
+)
+
+// ErrBehind reports a database missing migrations of the binary.
+var ErrBehind = errors.New("database schema is behind")
+
+// Migration is one version of the schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// String returns the file name of the migration without its direction,
+// 0001_create_users for instance.
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Status is the state of a migration in a database.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// schemaMigration is a row of the schema_migrations table.
+type schemaMigration struct {
+	Version   int       `gorm:"primary_key;auto_increment:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Load returns the migrations of driver in version order.
+func Load(driver string) ([]Migration, error) {
+	scripts, err := fs.NewWithNamespace(migrations.Migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	err = fs.Walk(scripts, "/"+driver, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		version, name, direction, err := parseName(path.Base(p))
+		if err != nil {
+			return err
+		}
+		script, err := readFile(scripts, p)
+		if err != nil {
+			return err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return fmt.Errorf("migration %04d is named both %s and %s", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = script
+		} else {
+			m.Down = script
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no migrations for database driver %q", driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s needs both an up and a down script", m)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// parseName splits the file name of a script, 0001_create_users.up.sql for
+// instance.
+func parseName(file string) (version int, name, direction string, err error) {
+	base := strings.TrimSuffix(file, ".sql")
+	dot := strings.LastIndexByte(base, '.')
+	underscore := strings.IndexByte(base, '_')
+	if base == file || dot < 0 || underscore < 0 || underscore > dot {
+		return 0, "", "", fmt.Errorf("invalid migration file name %q", file)
+	}
+	direction = base[dot+1:]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("invalid migration file name %q: %q is neither up nor down", file, direction)
+	}
+	version, err = strconv.Atoi(base[:underscore])
+	if err != nil || version < 1 {
+		return 0, "", "", fmt.Errorf("invalid migration file name %q: the version must be a positive number", file)
+	}
+	return version, base[underscore+1 : dot], direction, nil
+}
+
+func readFile(fs http.FileSystem, name string) (string, error) {
+	f, err := fs.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	return string(data), err
+}
+
+// statements splits a script into the statements ending each with a semicolon
+// at the end of a line, as drivers do not all run several statements at once.
+func statements(script string) []string {
+	var list []string
+	var current strings.Builder
+	for _, line := range strings.SplitAfter(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if current.Len() == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		current.WriteString(line)
+		if strings.HasSuffix(trimmed, ";") {
+			list = append(list, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		list = append(list, s)
+	}
+	return list
+}
+
+// Migrator applies the migrations of a driver to a database.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New returns a Migrator applying the migrations of driver to db.
+func New(db *gorm.DB, driver string) (*Migrator, error) {
+	list, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// applied returns the applied versions. A database without the
+// schema_migrations table has none.
+func (m *Migrator) applied() (map[int]schemaMigration, error) {
+	versions := make(map[int]schemaMigration)
+	if !m.db.HasTable(&schemaMigration{}) {
+		return versions, nil
+	}
+	var rows []schemaMigration
+	if err := m.db.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		versions[row.Version] = row
+	}
+	return versions, nil
+}
+
+// Status returns the state of every migration of the binary in the database.
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		row, ok := applied[migration.Version]
+		list[i] = Status{Migration: migration, Applied: ok, AppliedAt: row.AppliedAt}
+	}
+	return list, nil
+}
+
+// Check fails with ErrBehind if migrations of the binary are not applied to
+// the database. Versions applied by a newer binary are not an error, so that
+// instances can be upgraded after the database.
+func (m *Migrator) Check() error {
+	list, err := m.Status()
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, s := range list {
+		if !s.Applied {
+			pending = append(pending, s.Migration.String())
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w, pending migrations: %s", ErrBehind, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Up applies the pending migrations in version order and returns them. Each
+// migration is applied in a transaction of its own, MySQL however commits
+// every schema change on its own.
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}).Error; err != nil {
+		return nil, fmt.Errorf("creating the schema_migrations table: %w", err)
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := m.run(migration.Up, func(tx *gorm.DB) error {
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("applying migration %s: %w", migration, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverts the last applied migration and returns it, or nil when none is
+// applied.
+func (m *Migrator) Down() (*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	last := 0
+	for version := range applied {
+		if version > last {
+			last = version
+		}
+	}
+	if last == 0 {
+		return nil, nil
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version != last {
+			continue
+		}
+		err := m.run(migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&schemaMigration{Version: migration.Version}).Error
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reverting migration %s: %w", migration, err)
+		}
+		return &migration, nil
+	}
+	return nil, fmt.Errorf("migration %04d_%s was applied by a newer binary and cannot be reverted by this one", last, applied[last].Name)
+}
+
+// run executes script then record in one transaction.
+func (m *Migrator) run(script string, record func(tx *gorm.DB) error) error {
+	tx := m.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	for _, statement := range statements(script) {
+		if err := tx.Exec(statement).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,17 @@
+// Code generated by statik. DO NOT EDIT.
+
+// Package migrations embeds the SQL scripts of src/migrate/scripts.
+package migrations
+
+import (
+	"github.com/rakyll/statik/fs"
+)
+
+
+const Migrations = "migrations" // static asset namespace
+
+func init() {
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00mysql/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1e\x00\xe1\xffDROP TABLE IF EXISTS `users`;\n\x03\x00PK\x07\x08\x9c;\xe4V%\x00\x00\x00\x1e\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00mysql/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8\x9c\xcc\xb1N\xc30\x10\xc6\xf1\xb9y\x8a\x1b\x13\x89\xa1 ub2\xe9U\xb2H\xdc\xe2\x9c%:\xe5\xac\xd8\x02\x0bj*;\x81\xd7G\xce\xc2\x02\x0b\xf3\xf7\xfb\xfe\xadFA\x08$\x1e:\x04y\x00u$\xc0g9\xd0\x00\xbcd\x9f2C]m88\x86\x10gXb\x0e/\xd1;\x10\x86\x8e\xa3T\xad\xc6\x1e\x15\xddT\x1b\x8eaz\x8b\xf6\xe2\x19>m\x9a^m\xaa\xefv\xbbf\x0d*\xd3u`\x94|2X\xa4\xbf\xd8\xf0\xfe\xc3n\xb7\xdb_\xd9\xd5\xe6\xfc\xf5\x91\xdc\x1f\xb2\x94\xa6\xe4\xed\xec\xddhg\x86\xbd $\xd9\xe3:\xc2\x1e\x0f\xc2t\x04\xad\xd1\x1a\x15\x8de\x19H\xf4\xa7r[\xae\xee\x1f\xb7\x93\x96\xbd\xd0gx\xc43\xd4\x1c\x1c7Us_}\x0f\x00PK\x07\x08\xbc\xf9\x08\xe9\xc3\x00\x00\x00A\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00mysql/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1f\x00\xe0\xffDROP TABLE IF EXISTS `events`;\n\x03\x00PK\x07\x08\xb0Q4\x0d&\x00\x00\x00\x1f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1f\x00	\x00mysql/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8\x9c\x901o\x830\x10\x85g\xf8\x157\x82\x94\xa9R\xa6NWsTV\xc1Ps\x96\x9a	S\xb0\x12\xa4\xca\xa9\xc0\xe4\xf7WN[E\x1d\xbad}\xef{\xef\x9dNhB&`|\xaa\x08d	\xaaa\xa07\xd9q\x07\xd6]\x9c\x0f\xab\x85,M\xec<Yx\x9f\x8f\xb3\x0f\xb0\xf9u>z7\x01\x1anz\xa9\x84\xa6\x9a\x14\xef\xd2\xc4\x869|8\x0b\x97a\x19O\xc3\x92=\xec\xf7\xf9\xb5Q\x99\xaa\x02\xa3\xe4\xab\xa1\x88\x8dg\x1f\x9c\x0f\xff\x80\x91\x18\xb6p:/}\\\xfd3\xf9[\x16\x99qqCpS?\x04\x0b\x052\xb1\xac\xe9jBA%\x9a\x8aA\x18\xadIq\x1f\x9d\x8e\xb1ncl\xfb\x9c\xee\x88\xb5Z\xd6\xa8\x0f\xf0B\x07\xc8\xe27\xf2]\x9a\x94\x8d&\xf9\xac~\xc4\xdb\xcd9h*I\x93\x12\xd4\x81\xddV\xb7\xac\xf6;\x04\x8d\x82\x82*b\x02\x81\x9d\xc0\x82\xa2b\xda\x02oJ\x9a?\xa6_\x03\x00PK\x07\x08Y\xf4>6\xf1\x00\x00\x00\x97\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00mysql/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8\x00)\x00\xd6\xffDROP TABLE IF EXISTS `idempotency_keys`;\n\x03\x00PK\x07\x08^\x9c\x17/0\x00\x00\x00)\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00)\x00	\x00mysql/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8d\x90?o\xc20\x14\xc4g\xf2)\xde\x98H\x9dh\xe9\xd2\xc9\x05#E\x0d)\nF\x82\xc9v\x92\x07D$\xb6e[\x15\xf9\xf6\x95\x19\x9a?]\xef~w\xf6\xbbuA	\xa3\xc0\xc8gF!\xddB\xfe\xcd\x80\x9e\xd2\x03;\x80hj\xec\x8c\xf6\xa8\xaa\x9e\xdf\xb1w\x02\xe2h1W\x05\xfcH[\xdd\xa4\x8d\x97\xabU\xf2\x12-\x84\xd7wT\x83\xfc\xbaL\x9e\xad\xf91\xcb\x82\xdd\xa1\xbf\xe9z\x1a\x9b\x00\x97F]\xd1\x1a\xdb(?P\xefoS\xc8\xa23Z9\xe4\xbe78-\x1b\xdb\x02Z\xad\xaee\xab\xcb\xa0V\xba3-z\xac\x05\x94Z\xb7(\xd5_'l\xe8\x96\x1c3\x06\x17\xd9:|\xc2\x16\xa5\xc7\x9aK/`C\x18e\xe9\x8eN\xd1\xf5\xb1(h\xcexp\x0e\x8c\xec\xf6!\x86\x0f\xd3Xt\xb3\xd8p\xde\xbeHw\xa48\xc3\x17=C\xfco\xcc\xf0\xf94\xdf\xd0SX\xff\xc1g\xb6\xe3\xe3\xf6x\xfcV\x12%\x1f\xd1\xef\x00PK\x07\x08\xf9 @K\xf6\x00\x00\x00\xcd\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00postgres/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1e\x00\xe1\xffDROP TABLE IF EXISTS \"users\";\n\x03\x00PK\x07\x08\xb3I\x9e\xd5%\x00\x00\x00\x1e\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00postgres/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8\xa4\xcc\xb1j\xc30\x10\xc6\xf19~\x8a\xe3&\x1b:\xa4\x85L\x9d\xd4\xf4\x02\xa2\xb6\x9b\xca\x124S8\xac\x83\x88\xc6\x8e\x91\x94\x06\xfa\xf4\xc5^\xba\xb4S\xc7\x0f~\xdf\x7fkHY\x02\xab\x9ej\x02\xbd\x83\xf6\xd5\x02\xbd\xeb\xcev\x80\xd7$1!\x94\xc5\n\x83GH\x12\x03\x9f\xef\x8a\x15\x8e\xa1\xff\x18y\x10\x84O\x8e\xfd\x89c\xf9\xb0\xd9T\xcb\xb7uu\x0d\xae\xd5o\x8ef)\x03\x87\xf3\x0f\xbb_\xaf\x7fe\x13\xa7t\xbbD\xff\x87\x9cK}\x14\xce\xe2\x8f\x9c\x11r\x18$e\x1e&\xb8\x85|Z&|]F\x81g\xda)W[\xd8:c\xa8\xb5G\xab\x1b\xea\xacj\xf6s\xe1:\xf9\xff\x15\xf6F7\xca\x1c\xe0\x85\x0ePb\xf0X\x15\xd5c\xf1=\x00PK\x07\x08HcJ\xeb\xc1\x00\x00\x00B\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00postgres/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1f\x00\xe0\xffDROP TABLE IF EXISTS \"events\";\n\x03\x00PK\x07\x08\x83\xdfe\xe4&\x00\x00\x00\x1f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00postgres/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8\xa4\x8e\xb1n\x830\x10@\xe7\xf0\x15'O u\xaa\x94\xa9\x93\x0b\x87\x84\n\x0e5\xb6\xd4L\xc8\x85S\xb0\x94@d\x8eT\xea\xd7W\xce\x92\xa9S\xc6{z\xf7\xeer\x8d\xd2 \x18\xf9^#T%\xa8\x83\x01\xfc\xaa:\xd3\x81\xa0\x1b\xcd\xbc\nH\x93\x9d\xf0\xa3\x80o\x7fZ)xw~Iv\x82=\x9fI\xc0\xcd\x85ar!}\xdd\xef\xb3\xfb\xb2\xb2u\x0dVU\x9f\x16\xa36,3\xd3\xcc\xff\x88\xd1p\x1bOK\xe8\xe3\x01?3\x9d(<:\x1aK\xd4\xa8r\xec@l+\x85U\xa4\xf1\x93\x0c\x0e\n\n\xac\xd1 \xe4\xb2\xcbe\x81\x91\xd8\xb6\x90\x0f\x12\xdbC \xc74\xf6\x8e\x05\xb0\xbf\xd0\xca\xeer\x85\x1f\xcf\xd3}\x84\xdfe&(\xb0\x94\xb66\x90[\xadQ\x99\xdeT\x0dvF6m,l\xd7\xf1\xb9B\xab\xabF\xea#|\xe0\x11R\xe1G\x91%\xd9[\xf27\x00PK\x07\x08\x82:tf\xe4\x00\x00\x00w\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00postgres/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8\x00)\x00\xd6\xffDROP TABLE IF EXISTS \"idempotency_keys\";\n\x03\x00PK\x07\x08j\x13?\xa30\x00\x00\x00)\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00postgres/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8|\x91\xc1n\xf20\x10\x84\xcf\xe4)V>%\x12'\xfe\x9f^8\xa5`\xa4\xa8!\xa0`$8Y\x86,\xc5\x82\xd8\x96\xbdjI\x9f\xbe\x02\xb5@\xd2\xaaG\xcf\xce|\x1ay\xc6%O\x05\x07\x91>\xe7\x1c\xb2)\x14s\x01|\x9d-\xc5\x12\x98\xae\xb0v\x96\xd0\xec\x1ay\xc4&0\x88\xa3^We\xf0\xa6\xfc\xee\xa0|<\x18\x0e\x93~\xd4cd\x8fh\xee\xf2\xbfAr\xa5\x16\xab<\xbf\x9ck\xa4\x83\xad\xda\xb1\x96a\xaf\xcd+z\xe7\xb5\xa1\xbb\xeb\xe9\x7f\xdb\xe418k\x02Jj\x1c\xb6a\x8fg\x06\xdb\x86P]\xa4\x9d\xad\xdd		+\x06[kO\xa8\xcc\x0d\x08\x13>MW\xb9\x80\xbd:\x05\xbc\x9a=*\xc2J*b@\xba\xc6@\xaav\xf0\xae\xe9p}\xc2\x875xK\x8dWe\xc9\x0b!E6\xe3K\x91\xce\x16\x17\x02\x9e\x9d\xf6\x18\xfe&|\x17\xe8G\xbdE\x99\xcd\xd2r\x03/|\x03\xf1\x8fON\xa2d\x14}m\x95\x15\x13\xbe\xeel\xa5\xab\xb3\xecd\x82\xbcW\x80y\xf1\xcb\x9c\xf1c\xc9d\x14}\x0e\x00PK\x07\x08\x18a\xcc+\x0f\x01\x00\x00\x0c\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00sqlite3/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1e\x00\xe1\xffDROP TABLE IF EXISTS \"users\";\n\x03\x00PK\x07\x08\xb3I\x9e\xd5%\x00\x00\x00\x1e\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00sqlite3/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8\x94\xcc\xb1N\xc30\x10\x80\xe1\xb9y\x8a\x93\xa7Vb(H\x9d\x98B\xb9J\x91\xd2\x00\xc9Yb\xabN\xf6	\xacb7:;\xa0\xbc=\n\x0b\x0b\x0c\xdd\xbf\xff\xdf\xf7X\x13\x02\xd5\x0f-Bs\x80\xee\x89\x00_\x9b\x81\x060S\x16\xcd\x06\xd6\xd5\xca\x04o \xa4\"o\xa20j\x88\xac3\x9ce\x06\x9e\xca%$\xa7\x12%\x95\x9bjeRp\xe7\xc4Q\x0c|\xb2\xbaw\xd6\xf5\xddn\xb7\xf9\xd9v\xb6m\xc1v\xcd\x8b\xc5EJ\xe4\xf0\xf1\xcbn\xb7\xdb?\xd9\xc89\x7f]\xd4\xff#\x97\x93S\xe1\"\xfe\xc4\xc5\x80\xe7\"%D\x81G<\xd4\xb6%\xd8\xdb\xbe\xc7\x8eN\xd4\x1cq\xa0\xfa\xf8\xbc\x14\xd3\xe8\xaf(\xaa\xcd}\xf5=\x00PK\x07\x08\x93\xd0o\xaa\xba\x00\x00\x00(\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00sqlite3/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8\x00\x1f\x00\xe0\xffDROP TABLE IF EXISTS \"events\";\n\x03\x00PK\x07\x08\x83\xdfe\xe4&\x00\x00\x00\x1f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00sqlite3/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8\x94\x8e1k\xbc@\x10Gk\xfd\x14\xc3T\nW\xfd\xe1\xaa\x7f\xb5\xd1\x11\x04o\xef\xa2\xbb\x90N\x16\x1drK\xe2z\xac\xe3\xc1}\xfb\xb0)\x924)\xd2\xfex\xef\xcdT=)C`\xd4SG\xd06\xa0\xcf\x06\xe8\xa5\x1d\xcc\x00\xc8w\x0e\xb2!\x14y\x86~F\xf0A\xf8\x95#\xdc\xa2_\\|\xc0\x1b?\xc0\xed\xb2\xfa0E^8\xc8!\xcfP\xbc\xbc3\xc2\xdd\xc5\xe9\xeab\xf1\xefx,?\xa3\xdav\x1dX\xdd>[:\xe4\x19Nk\x10\x0e\xf2\x0b\x98\x08\xb7\xcbu\x8d\xe3\xcf\xc3_\x9d\x9e\x1a\xeaIW4\x00\xee\x1b\xc7\x0d\x8b\xf4a	g\x0d5ud\x08*5T\xaa\xa6\xb4\xd8K\xad\xbe\x97\xd4\x9e\";\xe1yt\x820;a\xf1\x0bCM\x8d\xb2\x9d\x81\xca\xf6=i3\x9a\xf6D\x83Q\xa7K2\xf6\xdb\xfc\x07#/\xff\xe7\x1f\x03\x00PK\x07\x08\xed\x17\xf2^\xdc\x00\x00\x00Z\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00-\x00	\x00sqlite3/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8\x00)\x00\xd6\xffDROP TABLE IF EXISTS \"idempotency_keys\";\n\x03\x00PK\x07\x08j\x13?\xa30\x00\x00\x00)\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00sqlite3/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8l\x911o\xc20\x14\x84g\xfc+\x9e<%\x12\x13-]\x98R0R\xd4\x10P0\x12L\x96\xc1\x8fb\x91\xc4\x96mU\xe4\xdfWA-!i\xd7{\xdf\xddY\xbey\xc1\x12\xce\x80'\xef\x19\x83t	\xf9\x9a\x03\xdb\xa7[\xbe\x05\xaa\x15V\xd6\x04\xacO\x8d\xb8b\xe3)Dd4T)|Iw\xbaH\x17M\xa6\xd3xLF4\x98+\xd6\x9d\xfc2\x89\xef\xa9\xf9.\xcb\xdas\x85\xe1bT\xdf\xd6\x03\xce\xba\xfeDg\x9d\xaeCG\xbd\xbd\xf6!\x87\xde\x9a\xda\xa3\x08\x8d\xc5~\xd8\xf3\x99\xc2\xb14\xc7V9\x99\xca\x96\x18PQ8\x1aS>\xc2`\xc1\x96\xc9.\xe3p\x96\xa5\xc7;\xe9P\x06TB\x06\nJ\x06\x0c\xba\xc2\x075\xdf\x15\x05\xcb\xb9\xe0\xe9\x8amy\xb2\xda\xb4\x0e\xbcY\xed\xd0\xf7\x1d\xbf\x05c2\xda\x14\xe9*)\x0e\xf0\xc1\x0e\x10\xfd\xf9\xc0\x98\xc43\xf2\xb3C\x9a/\xd8~\xb0\x83V71\xf0x\xd1U\xc2:\xffg\xaa\xe8\xf9Q\xf1\x8c|\x0f\x00PK\x07\x08>x\xdd\x96\x00\x01\x00\x00\xe8\x01\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x9c;\xe4V%\x00\x00\x00\x1e\x00\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00mysql/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xbc\xf9\x08\xe9\xc3\x00\x00\x00A\x01\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81|\x00\x00\x00mysql/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb0Q4\x0d&\x00\x00\x00\x1f\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x94\x01\x00\x00mysql/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(Y\xf4>6\xf1\x00\x00\x00\x97\x01\x00\x00\x1f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x12\x02\x00\x00mysql/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(^\x9c\x17/0\x00\x00\x00)\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Y\x03\x00\x00mysql/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xf9 @K\xf6\x00\x00\x00\xcd\x01\x00\x00)\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xeb\x03\x00\x00mysql/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb3I\x9e\xd5%\x00\x00\x00\x1e\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81A\x05\x00\x00postgres/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(HcJ\xeb\xc1\x00\x00\x00B\x01\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc0\x05\x00\x00postgres/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x83\xdfe\xe4&\x00\x00\x00\x1f\x00\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd9\x06\x00\x00postgres/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x82:tf\xe4\x00\x00\x00w\x01\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Z\x07\x00\x00postgres/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(j\x13?\xa30\x00\x00\x00)\x00\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x97\x08\x00\x00postgres/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x18a\xcc+\x0f\x01\x00\x00\x0c\x02\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81,	\x00\x00postgres/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb3I\x9e\xd5%\x00\x00\x00\x1e\x00\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9e\n\x00\x00sqlite3/0001_create_users.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x93\xd0o\xaa\xba\x00\x00\x00(\x01\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1c\x0b\x00\x00sqlite3/0001_create_users.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x83\xdfe\xe4&\x00\x00\x00\x1f\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81-\x0c\x00\x00sqlite3/0002_create_events.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xed\x17\xf2^\xdc\x00\x00\x00Z\x01\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xad\x0c\x00\x00sqlite3/0002_create_events.up.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(j\x13?\xa30\x00\x00\x00)\x00\x00\x00-\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe1\x0d\x00\x00sqlite3/0003_create_idempotency_keys.down.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(>x\xdd\x96\x00\x01\x00\x00\xe8\x01\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81u\x0e\x00\x00sqlite3/0003_create_idempotency_keys.up.sqlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x12\x00\x12\x00r\x06\x00\x00\xd7\x0f\x00\x00\x00\x00"
+		fs.RegisterWithNamespace("migrations", data)
+	}
+	
\ No newline at end of file
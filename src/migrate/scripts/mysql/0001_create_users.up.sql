@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `users` (
+	`id` int unsigned AUTO_INCREMENT,
+	`nickname` varchar(255) NOT NULL UNIQUE,
+	`email` varchar(100) NOT NULL UNIQUE,
+	`password` varchar(100) NOT NULL,
+	`created_at` DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	`updated_at` DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `idempotency_keys` (
+	`idempotency_key` varchar(255),
+	`token` varchar(32) NOT NULL,
+	`method` varchar(255) NOT NULL,
+	`fingerprint` varchar(64) NOT NULL,
+	`response_type` varchar(255),
+	`response` longblob,
+	`completed` boolean NOT NULL DEFAULT false,
+	`created_at` DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+	`expires_at` DATETIME NOT NULL,
+	PRIMARY KEY (`idempotency_key`),
+	INDEX `idx_idempotency_keys_expires_at` (`expires_at`)
+);
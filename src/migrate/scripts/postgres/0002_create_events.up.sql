@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "events" (
+	"id" bigserial,
+	"title" varchar(255) NOT NULL UNIQUE,
+	"content" varchar(255) NOT NULL,
+	"author_id" integer NOT NULL REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+	"created_at" timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("id")
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "idempotency_keys" (
+	"idempotency_key" varchar(255),
+	"token" varchar(32) NOT NULL,
+	"method" varchar(255) NOT NULL,
+	"fingerprint" varchar(64) NOT NULL,
+	"response_type" varchar(255),
+	"response" bytea,
+	"completed" boolean NOT NULL DEFAULT false,
+	"created_at" timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+	"expires_at" timestamp with time zone NOT NULL,
+	PRIMARY KEY ("idempotency_key")
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON "idempotency_keys"("expires_at");
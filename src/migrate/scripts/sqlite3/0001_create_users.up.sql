@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "users" (
+	"id" integer primary key autoincrement,
+	"nickname" varchar(255) NOT NULL UNIQUE,
+	"email" varchar(100) NOT NULL UNIQUE,
+	"password" varchar(100) NOT NULL,
+	"created_at" datetime DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" datetime DEFAULT CURRENT_TIMESTAMP
+);
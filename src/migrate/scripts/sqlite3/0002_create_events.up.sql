@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "events" (
+	"id" integer primary key autoincrement,
+	"title" varchar(255) NOT NULL UNIQUE,
+	"content" varchar(255) NOT NULL,
+	"author_id" integer NOT NULL REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+	"created_at" datetime DEFAULT CURRENT_TIMESTAMP,
+	"updated_at" datetime DEFAULT CURRENT_TIMESTAMP
+);
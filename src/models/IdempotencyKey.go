@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey : stored outcome of a request sent with an Idempotency-Key
+type IdempotencyKey struct {
+	Key          string    `gorm:"column:idempotency_key;primary_key;size:255" json:"idempotency_key"`
+	Token        string    `gorm:"size:32;not null" json:"-"`
+	Method       string    `gorm:"size:255;not null" json:"method"`
+	Fingerprint  string    `gorm:"size:64;not null" json:"fingerprint"`
+	ResponseType string    `gorm:"size:255" json:"response_type"`
+	Response     []byte    `json:"response"`
+	Completed    bool      `gorm:"not null;default:false" json:"completed"`
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// LengthError reports a field too long for its column once HTML escaped by
+// Prepare, requests being validated before.
+type LengthError struct {
+	Field string
+	Max   int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s is longer than %d characters once HTML escaped", e.Field, e.Max)
+}
+
+// checkLength returns a LengthError if value, named field, has more than max
+// characters.
+func checkLength(field, value string, max int) error {
+	if utf8.RuneCountInString(value) > max {
+		return &LengthError{Field: field, Max: max}
+	}
+	return nil
+}
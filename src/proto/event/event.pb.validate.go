@@ -0,0 +1,1248 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: event/event.proto
+
+package event
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = ptypes.DynamicAny{}
+)
+
+// define the regex for a UUID once up-front
+var _event_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on GetEventRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *GetEventRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetEventId() <= 0 {
+		return GetEventRequestValidationError{
+			field:  "EventId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// GetEventRequestValidationError is the validation error returned by
+// GetEventRequest.Validate if the designated constraints aren't met.
+type GetEventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEventRequestValidationError) ErrorName() string { return "GetEventRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetEventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEventRequestValidationError{}
+
+// Validate checks the field values on DeleteEventRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *DeleteEventRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetEventId() <= 0 {
+		return DeleteEventRequestValidationError{
+			field:  "EventId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	if m.GetAuthorId() <= 0 {
+		return DeleteEventRequestValidationError{
+			field:  "AuthorId",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// DeleteEventRequestValidationError is the validation error returned by
+// DeleteEventRequest.Validate if the designated constraints aren't met.
+type DeleteEventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteEventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteEventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteEventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteEventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteEventRequestValidationError) ErrorName() string {
+	return "DeleteEventRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteEventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteEventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteEventRequestValidationError{}
+
+// Validate checks the field values on AddEventRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *AddEventRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetTitle()) > 255 {
+		return AddEventRequestValidationError{
+			field:  "Title",
+			reason: "value length must be at most 255 runes",
+		}
+	}
+
+	if !_AddEventRequest_Title_Pattern.MatchString(m.GetTitle()) {
+		return AddEventRequestValidationError{
+			field:  "Title",
+			reason: "value does not match regex pattern \"\\\\S\"",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetContent()) > 255 {
+		return AddEventRequestValidationError{
+			field:  "Content",
+			reason: "value length must be at most 255 runes",
+		}
+	}
+
+	if !_AddEventRequest_Content_Pattern.MatchString(m.GetContent()) {
+		return AddEventRequestValidationError{
+			field:  "Content",
+			reason: "value does not match regex pattern \"\\\\S\"",
+		}
+	}
+
+	if m.GetAuthorID() <= 0 {
+		return AddEventRequestValidationError{
+			field:  "AuthorID",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// AddEventRequestValidationError is the validation error returned by
+// AddEventRequest.Validate if the designated constraints aren't met.
+type AddEventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AddEventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AddEventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AddEventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AddEventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AddEventRequestValidationError) ErrorName() string { return "AddEventRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AddEventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAddEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AddEventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AddEventRequestValidationError{}
+
+var _AddEventRequest_Title_Pattern = regexp.MustCompile("\\S")
+
+var _AddEventRequest_Content_Pattern = regexp.MustCompile("\\S")
+
+// Validate checks the field values on UpdateEventRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *UpdateEventRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetID() <= 0 {
+		return UpdateEventRequestValidationError{
+			field:  "ID",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetTitle()) > 255 {
+		return UpdateEventRequestValidationError{
+			field:  "Title",
+			reason: "value length must be at most 255 runes",
+		}
+	}
+
+	if !_UpdateEventRequest_Title_Pattern.MatchString(m.GetTitle()) {
+		return UpdateEventRequestValidationError{
+			field:  "Title",
+			reason: "value does not match regex pattern \"\\\\S\"",
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetContent()) > 255 {
+		return UpdateEventRequestValidationError{
+			field:  "Content",
+			reason: "value length must be at most 255 runes",
+		}
+	}
+
+	if !_UpdateEventRequest_Content_Pattern.MatchString(m.GetContent()) {
+		return UpdateEventRequestValidationError{
+			field:  "Content",
+			reason: "value does not match regex pattern \"\\\\S\"",
+		}
+	}
+
+	if m.GetAuthorID() <= 0 {
+		return UpdateEventRequestValidationError{
+			field:  "AuthorID",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// UpdateEventRequestValidationError is the validation error returned by
+// UpdateEventRequest.Validate if the designated constraints aren't met.
+type UpdateEventRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateEventRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateEventRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateEventRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateEventRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateEventRequestValidationError) ErrorName() string {
+	return "UpdateEventRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateEventRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateEventRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateEventRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateEventRequestValidationError{}
+
+var _UpdateEventRequest_Title_Pattern = regexp.MustCompile("\\S")
+
+var _UpdateEventRequest_Content_Pattern = regexp.MustCompile("\\S")
+
+// Validate checks the field values on BatchAddEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchAddEventsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetEvents()) < 1 {
+		return BatchAddEventsRequestValidationError{
+			field:  "Events",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetEvents() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchAddEventsRequestValidationError{
+					field:  fmt.Sprintf("Events[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for BestEffort
+
+	return nil
+}
+
+// BatchAddEventsRequestValidationError is the validation error returned by
+// BatchAddEventsRequest.Validate if the designated constraints aren't met.
+type BatchAddEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchAddEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchAddEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchAddEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchAddEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchAddEventsRequestValidationError) ErrorName() string {
+	return "BatchAddEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchAddEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchAddEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchAddEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchAddEventsRequestValidationError{}
+
+// Validate checks the field values on BatchAddEventsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchAddEventsResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchAddEventsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// BatchAddEventsResponseValidationError is the validation error returned by
+// BatchAddEventsResponse.Validate if the designated constraints aren't met.
+type BatchAddEventsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchAddEventsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchAddEventsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchAddEventsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchAddEventsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchAddEventsResponseValidationError) ErrorName() string {
+	return "BatchAddEventsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchAddEventsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchAddEventsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchAddEventsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchAddEventsResponseValidationError{}
+
+// Validate checks the field values on BatchAddEventResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchAddEventResult) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchAddEventResultValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetStatus()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchAddEventResultValidationError{
+				field:  "Status",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// BatchAddEventResultValidationError is the validation error returned by
+// BatchAddEventResult.Validate if the designated constraints aren't met.
+type BatchAddEventResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchAddEventResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchAddEventResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchAddEventResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchAddEventResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchAddEventResultValidationError) ErrorName() string {
+	return "BatchAddEventResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchAddEventResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchAddEventResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchAddEventResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchAddEventResultValidationError{}
+
+// Validate checks the field values on BatchDeleteEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchDeleteEventsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetEvents()) < 1 {
+		return BatchDeleteEventsRequestValidationError{
+			field:  "Events",
+			reason: "value must contain at least 1 item(s)",
+		}
+	}
+
+	for idx, item := range m.GetEvents() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchDeleteEventsRequestValidationError{
+					field:  fmt.Sprintf("Events[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for BestEffort
+
+	return nil
+}
+
+// BatchDeleteEventsRequestValidationError is the validation error returned by
+// BatchDeleteEventsRequest.Validate if the designated constraints aren't met.
+type BatchDeleteEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchDeleteEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchDeleteEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchDeleteEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchDeleteEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchDeleteEventsRequestValidationError) ErrorName() string {
+	return "BatchDeleteEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchDeleteEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchDeleteEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchDeleteEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchDeleteEventsRequestValidationError{}
+
+// Validate checks the field values on BatchDeleteEventsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchDeleteEventsResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchDeleteEventsResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// BatchDeleteEventsResponseValidationError is the validation error returned by
+// BatchDeleteEventsResponse.Validate if the designated constraints aren't met.
+type BatchDeleteEventsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchDeleteEventsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchDeleteEventsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchDeleteEventsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchDeleteEventsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchDeleteEventsResponseValidationError) ErrorName() string {
+	return "BatchDeleteEventsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchDeleteEventsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchDeleteEventsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchDeleteEventsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchDeleteEventsResponseValidationError{}
+
+// Validate checks the field values on BatchDeleteEventResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *BatchDeleteEventResult) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for EventId
+
+	if v, ok := interface{}(m.GetStatus()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchDeleteEventResultValidationError{
+				field:  "Status",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// BatchDeleteEventResultValidationError is the validation error returned by
+// BatchDeleteEventResult.Validate if the designated constraints aren't met.
+type BatchDeleteEventResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchDeleteEventResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchDeleteEventResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchDeleteEventResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchDeleteEventResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchDeleteEventResultValidationError) ErrorName() string {
+	return "BatchDeleteEventResultValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchDeleteEventResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchDeleteEventResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchDeleteEventResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchDeleteEventResultValidationError{}
+
+// Validate checks the field values on WatchEventsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, an error is returned.
+func (m *WatchEventsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetStartRevision() < 0 {
+		return WatchEventsRequestValidationError{
+			field:  "StartRevision",
+			reason: "value must be greater than or equal to 0",
+		}
+	}
+
+	if m.GetAuthorID() < 0 {
+		return WatchEventsRequestValidationError{
+			field:  "AuthorID",
+			reason: "value must be greater than or equal to 0",
+		}
+	}
+
+	return nil
+}
+
+// WatchEventsRequestValidationError is the validation error returned by
+// WatchEventsRequest.Validate if the designated constraints aren't met.
+type WatchEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchEventsRequestValidationError) ErrorName() string {
+	return "WatchEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WatchEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchEventsRequestValidationError{}
+
+// Validate checks the field values on EventChange with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *EventChange) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Revision
+
+	// no validation rules for ChangeType
+
+	if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventChangeValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetChangedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventChangeValidationError{
+				field:  "ChangedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// EventChangeValidationError is the validation error returned by
+// EventChange.Validate if the designated constraints aren't met.
+type EventChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventChangeValidationError) ErrorName() string { return "EventChangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventChangeValidationError{}
+
+// Validate checks the field values on ListEventsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, an
+// error is returned.
+func (m *ListEventsRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	return nil
+}
+
+// ListEventsRequestValidationError is the validation error returned by
+// ListEventsRequest.Validate if the designated constraints aren't met.
+type ListEventsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListEventsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListEventsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListEventsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListEventsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListEventsRequestValidationError) ErrorName() string {
+	return "ListEventsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListEventsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListEventsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListEventsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListEventsRequestValidationError{}
+
+// Validate checks the field values on EventDTO with the rules defined in the
+// proto definition for this message. If any rules are violated, an error is returned.
+func (m *EventDTO) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for ID
+
+	// no validation rules for Title
+
+	// no validation rules for Content
+
+	// no validation rules for AuthorID
+
+	if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventDTOValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventDTOValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	return nil
+}
+
+// EventDTOValidationError is the validation error returned by
+// EventDTO.Validate if the designated constraints aren't met.
+type EventDTOValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventDTOValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventDTOValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventDTOValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventDTOValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventDTOValidationError) ErrorName() string { return "EventDTOValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventDTOValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventDTO.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventDTOValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventDTOValidationError{}
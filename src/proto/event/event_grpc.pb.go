@@ -21,6 +21,9 @@ type EventServiceClient interface {
 	AddEvent(ctx context.Context, in *AddEventRequest, opts ...grpc.CallOption) (*EventDTO, error)
 	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*EventDTO, error)
 	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventRequest, error)
+	BatchAddEvents(ctx context.Context, in *BatchAddEventsRequest, opts ...grpc.CallOption) (*BatchAddEventsResponse, error)
+	BatchDeleteEvents(ctx context.Context, in *BatchDeleteEventsRequest, opts ...grpc.CallOption) (*BatchDeleteEventsResponse, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (EventService_WatchEventsClient, error)
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (EventService_ListEventsClient, error)
 }
 
@@ -68,8 +71,58 @@ func (c *eventServiceClient) DeleteEvent(ctx context.Context, in *DeleteEventReq
 	return out, nil
 }
 
+func (c *eventServiceClient) BatchAddEvents(ctx context.Context, in *BatchAddEventsRequest, opts ...grpc.CallOption) (*BatchAddEventsResponse, error) {
+	out := new(BatchAddEventsResponse)
+	err := c.cc.Invoke(ctx, "/event.EventService/BatchAddEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) BatchDeleteEvents(ctx context.Context, in *BatchDeleteEventsRequest, opts ...grpc.CallOption) (*BatchDeleteEventsResponse, error) {
+	out := new(BatchDeleteEventsResponse)
+	err := c.cc.Invoke(ctx, "/event.EventService/BatchDeleteEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eventServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (EventService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventService_serviceDesc.Streams[0], "/event.EventService/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventService_WatchEventsClient interface {
+	Recv() (*EventChange, error)
+	grpc.ClientStream
+}
+
+type eventServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventServiceWatchEventsClient) Recv() (*EventChange, error) {
+	m := new(EventChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *eventServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (EventService_ListEventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_EventService_serviceDesc.Streams[0], "/event.EventService/ListEvents", opts...)
+	stream, err := c.cc.NewStream(ctx, &_EventService_serviceDesc.Streams[1], "/event.EventService/ListEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +161,9 @@ type EventServiceServer interface {
 	AddEvent(context.Context, *AddEventRequest) (*EventDTO, error)
 	UpdateEvent(context.Context, *UpdateEventRequest) (*EventDTO, error)
 	DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventRequest, error)
+	BatchAddEvents(context.Context, *BatchAddEventsRequest) (*BatchAddEventsResponse, error)
+	BatchDeleteEvents(context.Context, *BatchDeleteEventsRequest) (*BatchDeleteEventsResponse, error)
+	WatchEvents(*WatchEventsRequest, EventService_WatchEventsServer) error
 	ListEvents(*ListEventsRequest, EventService_ListEventsServer) error
 }
 
@@ -127,6 +183,15 @@ func (UnimplementedEventServiceServer) UpdateEvent(context.Context, *UpdateEvent
 func (UnimplementedEventServiceServer) DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventRequest, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
 }
+func (UnimplementedEventServiceServer) BatchAddEvents(context.Context, *BatchAddEventsRequest) (*BatchAddEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddEvents not implemented")
+}
+func (UnimplementedEventServiceServer) BatchDeleteEvents(context.Context, *BatchDeleteEventsRequest) (*BatchDeleteEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteEvents not implemented")
+}
+func (UnimplementedEventServiceServer) WatchEvents(*WatchEventsRequest, EventService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
 func (UnimplementedEventServiceServer) ListEvents(*ListEventsRequest, EventService_ListEventsServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
@@ -214,6 +279,63 @@ func _EventService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_BatchAddEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).BatchAddEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.EventService/BatchAddEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).BatchAddEvents(ctx, req.(*BatchAddEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_BatchDeleteEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).BatchDeleteEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/event.EventService/BatchDeleteEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).BatchDeleteEvents(ctx, req.(*BatchDeleteEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EventService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServiceServer).WatchEvents(m, &eventServiceWatchEventsServer{stream})
+}
+
+type EventService_WatchEventsServer interface {
+	Send(*EventChange) error
+	grpc.ServerStream
+}
+
+type eventServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventServiceWatchEventsServer) Send(m *EventChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _EventService_ListEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListEventsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -255,8 +377,21 @@ var _EventService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEvent",
 			Handler:    _EventService_DeleteEvent_Handler,
 		},
+		{
+			MethodName: "BatchAddEvents",
+			Handler:    _EventService_BatchAddEvents_Handler,
+		},
+		{
+			MethodName: "BatchDeleteEvents",
+			Handler:    _EventService_BatchDeleteEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _EventService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListEvents",
 			Handler:       _EventService_ListEvents_Handler,
@@ -21,6 +21,7 @@ type UserServiceClient interface {
 	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*UserDTO, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UserDTO, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserRequest, error)
+	BatchAddUsers(ctx context.Context, in *BatchAddUsersRequest, opts ...grpc.CallOption) (*BatchAddUsersResponse, error)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error)
 }
 
@@ -68,6 +69,15 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) BatchAddUsers(ctx context.Context, in *BatchAddUsersRequest, opts ...grpc.CallOption) (*BatchAddUsersResponse, error) {
+	out := new(BatchAddUsersResponse)
+	err := c.cc.Invoke(ctx, "/user.UserService/BatchAddUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (UserService_ListUsersClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_UserService_serviceDesc.Streams[0], "/user.UserService/ListUsers", opts...)
 	if err != nil {
@@ -108,6 +118,7 @@ type UserServiceServer interface {
 	AddUser(context.Context, *AddUserRequest) (*UserDTO, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UserDTO, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserRequest, error)
+	BatchAddUsers(context.Context, *BatchAddUsersRequest) (*BatchAddUsersResponse, error)
 	ListUsers(*ListUsersRequest, UserService_ListUsersServer) error
 }
 
@@ -127,6 +138,9 @@ func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserReq
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserRequest, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServiceServer) BatchAddUsers(context.Context, *BatchAddUsersRequest) (*BatchAddUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAddUsers not implemented")
+}
 func (UnimplementedUserServiceServer) ListUsers(*ListUsersRequest, UserService_ListUsersServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
@@ -214,6 +228,24 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_BatchAddUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAddUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchAddUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/BatchAddUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchAddUsers(ctx, req.(*BatchAddUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListUsersRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -255,6 +287,10 @@ var _UserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "BatchAddUsers",
+			Handler:    _UserService_BatchAddUsers_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/identity"
+)
+
+const (
+	// APIKeyKey is the incoming metadata key carrying the API key of the
+	// client. The gateway maps the X-API-Key HTTP header onto it.
+	APIKeyKey = "x-api-key"
+
+	forwardedForKey = "x-forwarded-for"
+)
+
+// KeyFunc identifies the client of a request, the limits apply to each client
+// separately. Requests for which it returns "" are not limited.
+type KeyFunc func(ctx context.Context) string
+
+// APIKeys returns a function reporting whether a key is one of keys, for
+// DefaultKey.
+func APIKeys(keys []string) func(key string) bool {
+	known := make(map[[sha256.Size]byte]bool, len(keys))
+	for _, key := range keys {
+		known[sha256.Sum256([]byte(key))] = true
+	}
+	return func(key string) bool {
+		return known[sha256.Sum256([]byte(key))]
+	}
+}
+
+// Networks returns a function reporting whether an IP address belongs to one
+// of the networks of cidrs, such as 10.0.0.0/8, for DefaultKey.
+func Networks(cidrs []string) (func(ip net.IP) bool, error) {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks[i] = network
+	}
+	return func(ip net.IP) bool {
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// DefaultKey identifies clients by the subject of their verified certificate,
+// see the identity package, then by their API key when knownKey, which may be
+// nil, reports it as known, and then by their IP address. Unknown keys are
+// ignored: clients cannot get a fresh bucket by making keys up. Requests from
+// a peer whose certificate trustedProxy accepts, or whose address
+// trustedNetwork does, are attributed to the address the proxy received them
+// from; either may be nil. Loopback peers are not trusted unless
+// trustedNetwork says so, any local process could claim to be a proxy.
+func DefaultKey(trustedProxy func(*x509.Certificate) bool, trustedNetwork func(ip net.IP) bool, knownKey func(key string) bool) KeyFunc {
+	return func(ctx context.Context) string {
+		if id, ok := identity.FromContext(ctx); ok {
+			return "user:" + id.Subject
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if keys := md.Get(APIKeyKey); len(keys) > 0 && keys[0] != "" && knownKey != nil && knownKey(keys[0]) {
+			// Keys are secrets, keep them out of shared stores
+			sum := sha256.Sum256([]byte(keys[0]))
+			return "key:" + hex.EncodeToString(sum[:16])
+		}
+		if ip := clientIP(ctx, md, trustedProxy, trustedNetwork); ip != "" {
+			return "ip:" + ip
+		}
+		return ""
+	}
+}
+
+func clientIP(ctx context.Context, md metadata.MD, trustedProxy func(*x509.Certificate) bool, trustedNetwork func(net.IP) bool) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if !isProxy(p, host, trustedProxy, trustedNetwork) {
+		return host
+	}
+
+	// The gateway appends the address it was connected from to the
+	// X-Forwarded-For it received, the entries before it are client supplied.
+	forwarded := md.Get(forwardedForKey)
+	if len(forwarded) == 0 {
+		return host
+	}
+	hops := strings.Split(forwarded[len(forwarded)-1], ",")
+	if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+		return last
+	}
+	return host
+}
+
+func isProxy(p *peer.Peer, host string, trustedProxy func(*x509.Certificate) bool, trustedNetwork func(net.IP) bool) bool {
+	if ip := net.ParseIP(host); ip != nil && trustedNetwork != nil && trustedNetwork(ip) {
+		return true
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || trustedProxy == nil || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return false
+	}
+	return trustedProxy(info.State.VerifiedChains[0][0])
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the buckets refilled to their
+// burst, which behave like missing ones.
+const sweepInterval = time.Minute
+
+// DefaultMaxBuckets is the number of buckets a MemoryStore keeps by default.
+const DefaultMaxBuckets = 100000
+
+// MemoryStore keeps token buckets in process memory. Limits are enforced per
+// instance: N instances let N times the limit through.
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxBuckets int
+	buckets    map[string]*list.Element
+	order      *list.List // of *bucket, most recently used first
+	lastSweep  time.Time
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+// NewMemoryStore returns an empty MemoryStore keeping at most maxBuckets
+// buckets. When full, the least recently used bucket is dropped, its client
+// getting a full bucket back.
+func NewMemoryStore(maxBuckets int) *MemoryStore {
+	return &MemoryStore{
+		maxBuckets: maxBuckets,
+		buckets:    make(map[string]*list.Element),
+		order:      list.New(),
+		lastSweep:  time.Now(),
+	}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for _, el := range s.buckets {
+			if b := el.Value.(*bucket); b.refill(now) >= float64(b.limit.Burst) {
+				s.remove(el)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	el, ok := s.buckets[key]
+	if ok {
+		s.order.MoveToFront(el)
+	} else {
+		el = s.order.PushFront(&bucket{key: key, tokens: float64(limit.Burst), last: now})
+		s.buckets[key] = el
+		for s.order.Len() > s.maxBuckets {
+			s.remove(s.order.Back())
+		}
+	}
+	b := el.Value.(*bucket)
+	b.limit = limit
+	tokens := b.refill(now)
+	if tokens < 1 {
+		return false, time.Duration((1 - tokens) / limit.Rate * float64(time.Second)), nil
+	}
+	b.tokens = tokens - 1
+	b.last = now
+	return true, 0, nil
+}
+
+// Len returns the number of buckets kept.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.buckets, el.Value.(*bucket).key)
+}
+
+// refill returns the tokens of b at now, without updating it.
+func (b *bucket) refill(now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.last).Seconds()*b.limit.Rate
+	return math.Min(tokens, float64(b.limit.Burst))
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+)
+
+// Limit is a token bucket: it holds up to Burst requests and refills at Rate
+// requests per second. A zero Rate does not limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether l lets every request through.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// Limits configures the limit of every RPC.
+type Limits struct {
+	// Default applies to the methods missing from Methods.
+	Default Limit
+	// Methods maps full method names, such as
+	// "/event.EventService/AddEvent", to their limit.
+	Methods map[string]Limit
+}
+
+// For returns the limit of method.
+func (l Limits) For(method string) Limit {
+	if limit, ok := l.Methods[method]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// Store holds the token buckets. Implementations shared between instances,
+// for instance on Redis, enforce the limits across a whole deployment.
+type Store interface {
+	// Take takes a token from the bucket of key, created full if missing.
+	// When the bucket is empty it returns false and how long until the next
+	// token is available.
+	Take(ctx context.Context, key string, limit Limit) (ok bool, retryAfter time.Duration, err error)
+}
+
+// UnaryServerInterceptor limits the RPCs each client, identified by key,
+// makes to every method. Requests over their limit fail with
+// ResourceExhausted and a RetryInfo detail telling when to retry. Requests
+// are let through if the store fails, rather than failing the whole API.
+func UnaryServerInterceptor(store Store, limits Limits, key KeyFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := take(ctx, store, limits, key, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor, limiting the streams opened.
+func StreamServerInterceptor(store Store, limits Limits, key KeyFunc) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := take(ss.Context(), store, limits, key, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func take(ctx context.Context, store Store, limits Limits, key KeyFunc, method string) error {
+	limit := limits.For(method)
+	if limit.Unlimited() {
+		return nil
+	}
+	client := key(ctx)
+	if client == "" {
+		return nil
+	}
+
+	ok, retryAfter, err := store.Take(ctx, method+" "+client, limit)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Rate limit store failed, letting the request through", zap.Error(err))
+		return nil
+	}
+	if ok {
+		return nil
+	}
+
+	// Clients retry after whole seconds, see Retry-After
+	retryAfter = time.Duration(math.Ceil(retryAfter.Seconds())) * time.Second
+	st, err := status.New(codes.ResourceExhausted, "Rate limit exceeded, retry later").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryAfter)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "Rate limit exceeded, retry later")
+	}
+	return st.Err()
+}
@@ -0,0 +1,153 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Fixtures are the records of fixture files. Users are identified by their
+// email and events by their title, the natural keys records are upserted by.
+type Fixtures struct {
+	Users    []User     `yaml:"users" json:"users"`
+	Events   []Event    `yaml:"events" json:"events"`
+	Generate []Generate `yaml:"generate" json:"generate"`
+}
+
+// User is a user of fixture files.
+type User struct {
+	Nickname string `yaml:"nickname" json:"nickname"`
+	Email    string `yaml:"email" json:"email"`
+	Password string `yaml:"password" json:"password"`
+}
+
+// Event is an event of fixture files, Author being the email of its author.
+type Event struct {
+	Title   string `yaml:"title" json:"title"`
+	Content string `yaml:"content" json:"content"`
+	Author  string `yaml:"author" json:"author"`
+}
+
+// Generate describes numbered users and events, for load tests that need
+// more records than worth writing down. The users are named
+// <prefix>-user-<n>, with <prefix>-user-<n>@example.com as email, and each
+// has events titled <prefix>-event-<n>-<m>.
+type Generate struct {
+	Prefix        string `yaml:"prefix" json:"prefix"`
+	Users         int    `yaml:"users" json:"users"`
+	EventsPerUser int    `yaml:"events_per_user" json:"events_per_user"`
+	Password      string `yaml:"password" json:"password"`
+}
+
+// records returns the users and events described by g.
+func (g Generate) records() ([]User, []Event) {
+	users := make([]User, 0, g.Users)
+	events := make([]Event, 0, g.Users*g.EventsPerUser)
+	for n := 1; n <= g.Users; n++ {
+		u := User{
+			Nickname: fmt.Sprintf("%s-user-%d", g.Prefix, n),
+			Email:    fmt.Sprintf("%s-user-%d@example.com", g.Prefix, n),
+			Password: g.Password,
+		}
+		users = append(users, u)
+		for m := 1; m <= g.EventsPerUser; m++ {
+			events = append(events, Event{
+				Title:   fmt.Sprintf("%s-event-%d-%d", g.Prefix, n, m),
+				Content: fmt.Sprintf("Event %d of %s", m, u.Nickname),
+				Author:  u.Email,
+			})
+		}
+	}
+	return users, events
+}
+
+// LoadSet reads the fixture files of a set, the .yaml, .yml and .json files
+// of dir/set, in name order.
+func LoadSet(dir, set string) (Fixtures, error) {
+	var all Fixtures
+	if set == "" || strings.ContainsAny(set, `/\`) || set == "." || set == ".." {
+		return all, fmt.Errorf("invalid fixture set %q", set)
+	}
+	setDir := filepath.Join(dir, set)
+	entries, err := ioutil.ReadDir(setDir)
+	if os.IsNotExist(err) {
+		return all, fmt.Errorf("unknown fixture set %q, %s does not exist", set, setDir)
+	}
+	if err != nil {
+		return all, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		switch filepath.Ext(e.Name()) {
+		case ".yaml", ".yml", ".json":
+			if !e.IsDir() {
+				files = append(files, filepath.Join(setDir, e.Name()))
+			}
+		}
+	}
+	if len(files) == 0 {
+		return all, fmt.Errorf("fixture set %q has no .yaml, .yml or .json file in %s", set, setDir)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		f, err := loadFile(file)
+		if err != nil {
+			return all, err
+		}
+		all.Users = append(all.Users, f.Users...)
+		all.Events = append(all.Events, f.Events...)
+		all.Generate = append(all.Generate, f.Generate...)
+	}
+	return all, nil
+}
+
+// loadFile reads and validates a fixture file.
+func loadFile(file string) (Fixtures, error) {
+	var f Fixtures
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return f, err
+	}
+	if filepath.Ext(file) == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		err = yaml.UnmarshalStrict(data, &f)
+	}
+	if err != nil {
+		return f, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	if err := f.validate(); err != nil {
+		return f, fmt.Errorf("%s: %w", file, err)
+	}
+	return f, nil
+}
+
+func (f Fixtures) validate() error {
+	for i, u := range f.Users {
+		if u.Nickname == "" || u.Email == "" || u.Password == "" {
+			return fmt.Errorf("users[%d]: a nickname, an email and a password are required", i)
+		}
+	}
+	for i, e := range f.Events {
+		if e.Title == "" || e.Content == "" || e.Author == "" {
+			return fmt.Errorf("events[%d]: a title, a content and an author are required", i)
+		}
+	}
+	for i, g := range f.Generate {
+		if g.Prefix == "" || g.Password == "" || g.Users < 1 || g.EventsPerUser < 0 {
+			return fmt.Errorf("generate[%d]: a prefix, a password and a positive number of users are required", i)
+		}
+	}
+	return nil
+}
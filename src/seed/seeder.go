@@ -1,63 +1,155 @@
+// Package seed upserts the users and events of fixture files, so that
+// seeding can run again without duplicating or failing on existing records.
 package seed
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
 
 	"github.com/RemyRanger/taktyl_core_grpc/src/models"
-	"github.com/jinzhu/gorm"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
 )
 
-var users = []models.User{
-	models.User{
-		Nickname: "Steven victor",
-		Email:    "steven@gmail.com",
-		Password: "password",
-	},
-	models.User{
-		Nickname: "Martin Luther",
-		Email:    "luther@gmail.com",
-		Password: "password",
-	},
+// Result counts the records of a seeding.
+type Result struct {
+	Created   int
+	Updated   int
+	Unchanged int
 }
 
-var events = []models.Event{
-	models.Event{
-		Title:   "Title 1",
-		Content: "Hello world 1",
-	},
-	models.Event{
-		Title:   "Title 2",
-		Content: "Hello world 2",
-	},
-}
+// outcome is what an upsert did.
+type outcome int
 
-// Load : init database struct
-func Load(db *gorm.DB) {
+const (
+	created outcome = iota
+	updated
+	unchanged
+)
 
-	err := db.Debug().DropTableIfExists(&models.Event{}, &models.User{}).Error
-	if err != nil {
-		log.Fatalf("cannot drop table: %v", err)
+func (r *Result) count(o outcome) {
+	switch o {
+	case created:
+		r.Created++
+	case updated:
+		r.Updated++
+	case unchanged:
+		r.Unchanged++
 	}
-	err = db.Debug().AutoMigrate(&models.User{}, &models.Event{}).Error
-	if err != nil {
-		log.Fatalf("cannot migrate table: %v", err)
+}
+
+func (r *Result) add(other Result) {
+	r.Created += other.Created
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+}
+
+// Run upserts the fixtures of the given sets of dir, see LoadSet, in order.
+// Each set is applied in a unit of work of its own, so a set that fails
+// leaves no record behind and the returned Result only counts the sets seeded
+// before it.
+func Run(ctx context.Context, dir string, sets []string, work storage.UnitOfWork) (Result, error) {
+	var total Result
+	for _, set := range sets {
+		f, err := LoadSet(dir, set)
+		if err != nil {
+			return total, err
+		}
+		var r Result
+		err = work.Do(ctx, func(users storage.UserRepository, events storage.EventRepository) error {
+			r, err = Apply(ctx, f, users, events)
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("seeding fixture set %s: %w", set, err)
+		}
+		total.add(r)
 	}
+	return total, nil
+}
 
-	err = db.Debug().Model(&models.Event{}).AddForeignKey("author_id", "users(id)", "cascade", "cascade").Error
-	if err != nil {
-		log.Fatalf("attaching foreign key error: %v", err)
+// Apply upserts the users then the events of f. Users are matched by email
+// and have their nickname and password updated, events are matched by title
+// and have their content updated.
+func Apply(ctx context.Context, f Fixtures, users storage.UserRepository, events storage.EventRepository) (Result, error) {
+	var r Result
+	allUsers, allEvents := f.Users, f.Events
+	for _, g := range f.Generate {
+		u, e := g.records()
+		allUsers = append(allUsers, u...)
+		allEvents = append(allEvents, e...)
 	}
 
-	for i := range users {
-		err = db.Debug().Model(&models.User{}).Create(&users[i]).Error
+	for i, u := range allUsers {
+		o, err := upsertUser(ctx, users, u)
 		if err != nil {
-			log.Fatalf("cannot seed users table: %v", err)
+			return r, fmt.Errorf("user %s: %w", allUsers[i].Email, err)
 		}
-		events[i].AuthorID = users[i].ID
-
-		err = db.Debug().Model(&models.Event{}).Create(&events[i]).Error
+		r.count(o)
+	}
+	for i, e := range allEvents {
+		o, err := upsertEvent(ctx, users, events, e)
 		if err != nil {
-			log.Fatalf("cannot seed events table: %v", err)
+			return r, fmt.Errorf("event %q: %w", allEvents[i].Title, err)
 		}
+		r.count(o)
+	}
+	return r, nil
+}
+
+func upsertUser(ctx context.Context, users storage.UserRepository, fixture User) (outcome, error) {
+	user := models.User{}
+	user.Prepare(fixture.Nickname, fixture.Email, fixture.Password)
+	if err := user.Validate(); err != nil {
+		return 0, err
+	}
+
+	existing, err := users.FindByEmail(ctx, user.Email)
+	if errors.Is(err, storage.ErrNotFound) {
+		_, err = users.Create(ctx, &user)
+		return created, err
+	}
+	if err != nil {
+		return 0, err
+	}
+	if existing.Nickname == user.Nickname && models.VerifyPassword(existing.Password, user.Password) == nil {
+		return unchanged, nil
+	}
+	_, err = users.Update(ctx, existing.ID, &user)
+	return updated, err
+}
+
+func upsertEvent(ctx context.Context, users storage.UserRepository, events storage.EventRepository, fixture Event) (outcome, error) {
+	author := models.User{}
+	author.Prepare("", fixture.Author, "")
+	u, err := users.FindByEmail(ctx, author.Email)
+	if errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("no user has the author email %s", fixture.Author)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	event := models.Event{}
+	event.Prepare(fixture.Title, fixture.Content, int32(u.ID))
+	if err := event.Validate(); err != nil {
+		return 0, err
+	}
+
+	existing, err := events.FindByTitle(ctx, event.Title)
+	if errors.Is(err, storage.ErrNotFound) {
+		_, err = events.Create(ctx, &event)
+		return created, err
+	}
+	if err != nil {
+		return 0, err
+	}
+	if existing.AuthorID != event.AuthorID {
+		return 0, errors.New("the event belongs to another author, authors cannot be changed")
+	}
+	if existing.Content == event.Content {
+		return unchanged, nil
 	}
+	_, err = events.Update(ctx, existing.ID, &event)
+	return updated, err
 }
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// runBatch runs a batch RPC of n items, field naming the repeated request
+// field, calling each for every item with the repositories to use. By default
+// the items are processed in one unit of work, rolled back on the first
+// failure, and that failure is returned. With bestEffort every item is
+// processed on its own and the outcome of every item is returned instead.
+// convert turns the errors into gRPC statuses.
+func (b *Backend) runBatch(ctx context.Context, field string, n int, bestEffort bool, convert func(error) error, each func(users storage.UserRepository, events storage.EventRepository, i int) error) ([]*spb.Status, error) {
+	if n > b.MaxBatchSize {
+		return nil, invalidArgument(field, fmt.Sprintf("at most %d items are allowed in a batch", b.MaxBatchSize))
+	}
+
+	statuses := make([]*spb.Status, n)
+	if bestEffort {
+		for i := 0; i < n; i++ {
+			// An OK status rather than none, as when processed together
+			st := status.New(codes.OK, "")
+			if err := each(b.users, b.events, i); err != nil {
+				st = status.Convert(convert(err))
+			}
+			statuses[i] = st.Proto()
+		}
+		return statuses, nil
+	}
+
+	failed := -1
+	err := b.work.Do(ctx, func(users storage.UserRepository, events storage.EventRepository) error {
+		for i := 0; i < n; i++ {
+			if err := each(users, events, i); err != nil {
+				failed = i
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// The unit of work itself may fail, committing for instance
+		if failed < 0 {
+			return nil, convert(err)
+		}
+		st := status.Convert(convert(err))
+		return nil, status.Errorf(st.Code(), "%s[%d]: %s", field, failed, st.Message())
+	}
+	for i := range statuses {
+		statuses[i] = status.New(codes.OK, "").Proto()
+	}
+	return statuses, nil
+}
+
+// invalidArgument returns an InvalidArgument status carrying a BadRequest
+// detail with one field violation.
+func invalidArgument(field, description string) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%s: %s", field, description))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// ConsistencyKey is the incoming metadata key a client sets to
+// ConsistencyStrong to have its reads go to the primary database. The gateway
+// maps the X-Consistency HTTP header onto it.
+const ConsistencyKey = "x-consistency"
+
+// ConsistencyStrong is the value of ConsistencyKey reading from the primary.
+const ConsistencyStrong = "strong"
+
+// writers remembers until when the reads of the clients that wrote go to the
+// primary, so that they read their writes despite the lag of the replicas.
+type writers struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+	swept time.Time
+}
+
+func newWriters() *writers {
+	return &writers{until: make(map[string]time.Time)}
+}
+
+func (w *writers) wrote(client string, window time.Duration) {
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.until[client] = now.Add(window)
+	// Forget the clients whose window ended, at most once per window
+	if now.Sub(w.swept) > window {
+		for c, until := range w.until {
+			if now.After(until) {
+				delete(w.until, c)
+			}
+		}
+		w.swept = now
+	}
+}
+
+func (w *writers) recent(client string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return time.Now().Before(w.until[client])
+}
+
+// wrote records that the client of ctx just wrote.
+func (b *Backend) wrote(ctx context.Context) {
+	if b.ReadYourWrites <= 0 || b.ClientKey == nil {
+		return
+	}
+	if client := b.ClientKey(ctx); client != "" {
+		b.writers.wrote(client, b.ReadYourWrites)
+	}
+}
+
+// readContext returns the context to read with: reads go to the primary when
+// the client asks for strong consistency or wrote within ReadYourWrites, and
+// may go to a replica otherwise.
+func (b *Backend) readContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(ConsistencyKey); len(values) > 0 && values[0] == ConsistencyStrong {
+		return storage.ReadPrimary(ctx)
+	}
+	if b.ReadYourWrites <= 0 || b.ClientKey == nil {
+		return ctx
+	}
+	if client := b.ClientKey(ctx); client != "" && b.writers.recent(client) {
+		return storage.ReadPrimary(ctx)
+	}
+	return ctx
+}
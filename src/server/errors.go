@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// toStatus converts an error returned by the repositories into a gRPC status
+// error. resource names the entity the RPC works on and is used in client
+// facing messages; storage errors that have no better code are logged with
+// the logger of ctx and reported as Internal without their raw text.
+func toStatus(ctx context.Context, err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var lengthErr *models.LengthError
+	switch {
+	case errors.As(err, &lengthErr):
+		return invalidArgument(lengthErr.Field, fmt.Sprintf("at most %d characters are allowed once HTML escaped", lengthErr.Max))
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Errorf(codes.NotFound, "%s not found", resource)
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "%s already exists", resource)
+	case errors.Is(err, storage.ErrMissingReference):
+		return status.Errorf(codes.FailedPrecondition, "%s references a missing record", resource)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Request deadline exceeded")
+	}
+
+	logging.FromContext(ctx).Error("Unexpected database error", zap.String("resource", resource), zap.Error(err))
+	return status.Errorf(codes.Internal, "Error while accessing %s in database", resource)
+}
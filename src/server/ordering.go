@@ -0,0 +1,56 @@
+package server
+
+import (
+	"sort"
+	"sync"
+)
+
+// lockStripes is the number of locks the IDs share in stripedLocks.
+const lockStripes = 64
+
+// stripedLocks locks IDs, those sharing no stripe being locked concurrently.
+//
+// The Backend orders with them the writes to each event with the publication
+// of their changes, so that watchers receive the changes of an event in the
+// order they were committed. Creations are published under the lock of the new
+// event once committed: a write to the event made before its ID is returned
+// may be published first.
+//
+// Creations of events share the lock of their author, which deleting the
+// author takes exclusively before locking the events it deletes along: those
+// are the ones it publishes the deletion of.
+type stripedLocks struct {
+	stripes [lockStripes]sync.RWMutex
+}
+
+// lock locks the stripes of ids, always in the same order, and returns the
+// function unlocking them.
+func (l *stripedLocks) lock(ids ...uint64) func() {
+	return l.each(ids, (*sync.RWMutex).Lock, (*sync.RWMutex).Unlock)
+}
+
+// rlock is lock taking the stripes shared with other rlock calls.
+func (l *stripedLocks) rlock(ids ...uint64) func() {
+	return l.each(ids, (*sync.RWMutex).RLock, (*sync.RWMutex).RUnlock)
+}
+
+func (l *stripedLocks) each(ids []uint64, lock, unlock func(*sync.RWMutex)) func() {
+	seen := make(map[int]bool, len(ids))
+	stripes := make([]int, 0, len(ids))
+	for _, id := range ids {
+		stripe := int(id % lockStripes)
+		if !seen[stripe] {
+			seen[stripe] = true
+			stripes = append(stripes, stripe)
+		}
+	}
+	sort.Ints(stripes)
+	for _, stripe := range stripes {
+		lock(&l.stripes[stripe])
+	}
+	return func() {
+		for _, stripe := range stripes {
+			unlock(&l.stripes[stripe])
+		}
+	}
+}
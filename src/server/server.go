@@ -1,46 +1,56 @@
 package server
 
 import (
-	"fmt"
-	"log"
-	"sync"
+	"context"
+	"time"
 
-	"github.com/RemyRanger/taktyl_core_grpc/src/models"
-	"github.com/jinzhu/gorm"
-
-	_ "github.com/jinzhu/gorm/dialects/postgres" //postgres database driver
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+	"github.com/RemyRanger/taktyl_core_grpc/src/watch"
 )
 
-// Backend implements the protobuf interface
+// DefaultMaxBatchSize is the number of items batch RPCs accept by default.
+const DefaultMaxBatchSize = 100
+
+// eventHistorySize is the number of event changes kept for WatchEvents to resume from.
+const eventHistorySize = 1000
+
+// Backend implements the protobuf interface. Its RPCs run concurrently, the
+// repositories keeping each write atomic and the unit of work those spanning
+// several of them. The writes to an event and the publication of its changes
+// are ordered by eventLocks, authorLocks being taken before them.
 type Backend struct {
-	mu *sync.RWMutex
-	DB *gorm.DB
+	users       storage.UserRepository
+	events      storage.EventRepository
+	work        storage.UnitOfWork
+	changes     *watch.Hub
+	authorLocks stripedLocks
+	eventLocks  stripedLocks
+	writers     *writers
+
+	// MaxBatchSize caps the number of items of batch RPCs.
+	MaxBatchSize int
+	// ReadYourWrites is how long the reads of a client, identified by
+	// ClientKey, go to the primary database after it wrote, rather than to a
+	// read replica that may not have its writes yet. Zero disables it.
+	ReadYourWrites time.Duration
+	ClientKey      func(ctx context.Context) string
 }
 
-// New initializes a new Backend struct.
-func New() *Backend {
+// New returns a Backend serving the users and events of the given
+// repositories, work running its multi-step writes on the same storage.
+func New(users storage.UserRepository, events storage.EventRepository, work storage.UnitOfWork) *Backend {
 	return &Backend{
-		mu: &sync.RWMutex{},
+		users:        users,
+		events:       events,
+		work:         work,
+		changes:      watch.NewHub(eventHistorySize),
+		writers:      newWriters(),
+		MaxBatchSize: DefaultMaxBatchSize,
 	}
 }
 
-// Initialize : init router and database link
-func (b *Backend) Initialize(Dbdriver, DbUser, DbPassword, DbPort, DbHost, DbName string) *Backend {
-
-	var err error
-
-	if Dbdriver == "postgres" {
-		DBURL := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s", DbHost, DbPort, DbUser, DbName, DbPassword)
-		b.DB, err = gorm.Open(Dbdriver, DBURL)
-		if err != nil {
-			fmt.Printf("Cannot connect to %s database", Dbdriver)
-			log.Fatal("This is the error:", err)
-		} else {
-			fmt.Printf("We are connected to the %s database \n", Dbdriver)
-		}
-	}
-
-	b.DB.Debug().AutoMigrate(&models.User{}) //, &models.Event{}) //database migration
-
-	return b
+// CancelStreams ends the WatchEvents streams with Unavailable and refuses new
+// ones, so that draining the server on shutdown does not wait for them.
+func (b *Backend) CancelStreams() {
+	b.changes.Close()
 }
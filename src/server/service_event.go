@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
@@ -10,162 +9,234 @@ import (
 
 	"github.com/RemyRanger/taktyl_core_grpc/src/models"
 	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+	"github.com/RemyRanger/taktyl_core_grpc/src/watch"
 )
 
 // GetEvent : get one event
 func (b *Backend) GetEvent(ctx context.Context, req *pbEvent.GetEventRequest) (*pbEvent.EventDTO, error) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	var err error
-	eventResult := models.Event{}
-	err = b.DB.Debug().Model(&models.Event{}).Where("id = ?", req.EventId).Take(&eventResult).Error
+	eventResult, err := b.events.Get(b.readContext(ctx), uint64(req.EventId))
 	if err != nil {
-		return &pbEvent.EventDTO{}, err
+		return &pbEvent.EventDTO{}, toStatus(ctx, err, "event")
 	}
-
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(eventResult.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(eventResult.UpdatedAt)
-
-	return &pbEvent.EventDTO{
-		ID:        int64(eventResult.ID),
-		Title:     eventResult.Title,
-		Content:   eventResult.Content,
-		AuthorID:  int32(eventResult.AuthorID),
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
-	}, nil
+	return eventDTO(eventResult), nil
 }
 
 // UpdateEvent adds a event to the database
 func (b *Backend) UpdateEvent(ctx context.Context, req *pbEvent.UpdateEventRequest) (*pbEvent.EventDTO, error) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	event := models.Event{}
 
 	event.Prepare(req.Title, req.Content, req.AuthorID)
-	err := event.Validate()
-	if err != nil {
-		return &pbEvent.EventDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while updating user in database: %v", err),
-		)
+	if err := event.Validate(); err != nil {
+		return &pbEvent.EventDTO{}, toStatus(ctx, err, "event")
 	}
-	eventUpdated, err := event.UpdateAEvent(b.DB, uint64(req.ID))
+	unlock := b.eventLocks.lock(uint64(req.ID))
+	defer unlock()
+	eventUpdated, err := b.events.Update(ctx, uint64(req.ID), &event)
 	if err != nil {
-		return &pbEvent.EventDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while updating user in database: %v", err),
-		)
+		return &pbEvent.EventDTO{}, toStatus(ctx, err, "event")
 	}
+	b.wrote(ctx)
 
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(eventUpdated.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(eventUpdated.UpdatedAt)
-
-	return &pbEvent.EventDTO{
-		ID:        int64(eventUpdated.ID),
-		Title:     eventUpdated.Title,
-		Content:   eventUpdated.Content,
-		AuthorID:  int32(eventUpdated.AuthorID),
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
-	}, nil
+	eventDTO := eventDTO(eventUpdated)
+	b.changes.Publish(pbEvent.EventChange_UPDATED, eventDTO)
+	return eventDTO, nil
 }
 
 // AddEvent : save one event
 func (b *Backend) AddEvent(ctx context.Context, req *pbEvent.AddEventRequest) (*pbEvent.EventDTO, error) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	var err error
-	eventCreated := models.Event{}
-	eventCreated.Prepare(req.Title, req.Content, req.AuthorID)
-	err = b.DB.Debug().Model(&models.Event{}).Create(&eventCreated).Error
-	if err != nil {
-		return &pbEvent.EventDTO{}, err
+	event := models.Event{}
+	event.Prepare(req.Title, req.Content, req.AuthorID)
+	if err := event.Validate(); err != nil {
+		return &pbEvent.EventDTO{}, toStatus(ctx, err, "event")
 	}
-	if eventCreated.ID != 0 {
-		err = b.DB.Debug().Model(&models.User{}).Where("id = ?", req.AuthorID).Take(&eventCreated.Author).Error
-		if err != nil {
-			return &pbEvent.EventDTO{}, err
-		}
+	unlockAuthor := b.authorLocks.rlock(uint64(event.AuthorID))
+	defer unlockAuthor()
+	eventCreated, err := b.events.Create(ctx, &event)
+	if err != nil {
+		return &pbEvent.EventDTO{}, toStatus(ctx, err, "event")
 	}
+	b.wrote(ctx)
 
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(eventCreated.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(eventCreated.UpdatedAt)
-
-	return &pbEvent.EventDTO{
-		ID:        int64(eventCreated.ID),
-		Title:     eventCreated.Title,
-		Content:   eventCreated.Content,
-		AuthorID:  int32(eventCreated.AuthorID),
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
-	}, nil
+	eventDTO := eventDTO(eventCreated)
+	unlock := b.eventLocks.lock(eventCreated.ID)
+	defer unlock()
+	b.changes.Publish(pbEvent.EventChange_CREATED, eventDTO)
+	return eventDTO, nil
 }
 
 // ListEvents lists all events in the store.
 func (b *Backend) ListEvents(_ *pbEvent.ListEventsRequest, srv pbEvent.EventService_ListEventsServer) error {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+	err := b.events.List(b.readContext(srv.Context()), func(e *models.Event) error {
+		return srv.Send(eventDTO(e))
+	})
+	if err != nil {
+		return toStatus(srv.Context(), err, "event")
+	}
+	return nil
+}
 
-	var err error
-	rows, err := b.DB.Model(&models.Event{}).Rows()
+// DeleteEvent delete one event in the database.
+func (b *Backend) DeleteEvent(ctx context.Context, req *pbEvent.DeleteEventRequest) (*pbEvent.DeleteEventRequest, error) {
+	unlock := b.eventLocks.lock(uint64(req.EventId))
+	defer unlock()
+	rowAffected, err := b.events.Delete(ctx, storage.EventRef{ID: uint64(req.EventId), AuthorID: uint32(req.AuthorId)})
 	if err != nil {
-		return err
+		return &pbEvent.DeleteEventRequest{}, toStatus(ctx, err, "event")
+	}
+	b.wrote(ctx)
+
+	if rowAffected > 0 {
+		b.changes.Publish(pbEvent.EventChange_DELETED, &pbEvent.EventDTO{
+			ID:       req.EventId,
+			AuthorID: int32(req.AuthorId),
+		})
 	}
 
-	defer rows.Close()
+	return &pbEvent.DeleteEventRequest{
+		EventId:  rowAffected,
+		AuthorId: req.AuthorId,
+	}, nil
+}
 
-	for rows.Next() {
-		var event models.Event
-		// ScanRows is a method of `gorm.DB`, it can be used to scan a row into a struct
-		err := b.DB.ScanRows(rows, &event)
-		if err != nil {
-			return status.Errorf(
-				codes.Internal,
-				fmt.Sprintf("Error while reading database iteration: %v", err),
-			)
+// WatchEvents streams the changes made to events.
+func (b *Backend) WatchEvents(req *pbEvent.WatchEventsRequest, srv pbEvent.EventService_WatchEventsServer) error {
+	var filter watch.Filter
+	if req.AuthorID > 0 {
+		filter = func(change *pbEvent.EventChange) bool {
+			return change.Event.GetAuthorID() == req.AuthorID
 		}
+	}
 
-		// Convert timestamp
-		createdAtTimesStamp, err := ptypes.TimestampProto(event.CreatedAt)
-		updatedAtTimesStamp, err := ptypes.TimestampProto(event.UpdatedAt)
-
-		// do something
-		srv.Send(&pbEvent.EventDTO{
-			ID:        int64(event.ID),
-			Title:     event.Title,
-			Content:   event.Content,
-			AuthorID:  int32(event.AuthorID),
-			CreatedAt: createdAtTimesStamp,
-			UpdatedAt: updatedAtTimesStamp,
-		})
+	watcher, err := b.changes.Watch(req.StartRevision, filter)
+	switch {
+	case err == watch.ErrCompacted, err == watch.ErrFutureRevision:
+		return status.Errorf(codes.OutOfRange, "Cannot resume from revision %d: %v, list events again and watch from revision 0", req.StartRevision, err)
+	case err == watch.ErrClosed:
+		return status.Error(codes.Unavailable, "Server is shutting down")
+	case err != nil:
+		return toStatus(srv.Context(), err, "event")
+	}
+	defer watcher.Close()
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, srv.Context().Err().Error())
+		case change, ok := <-watcher.C:
+			if !ok {
+				if watcher.Err() == watch.ErrClosed {
+					return status.Error(codes.Unavailable, "Server is shutting down")
+				}
+				return status.Error(codes.Aborted, "Watcher fell behind, resume from the last received revision")
+			}
+			if err := srv.Send(change); err != nil {
+				return err
+			}
+		}
 	}
-	return nil
 }
 
-// DeleteEvent delete one event in the database.
-func (b *Backend) DeleteEvent(ctx context.Context, req *pbEvent.DeleteEventRequest) (*pbEvent.DeleteEventRequest, error) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+// BatchAddEvents adds several events to the database.
+func (b *Backend) BatchAddEvents(ctx context.Context, req *pbEvent.BatchAddEventsRequest) (*pbEvent.BatchAddEventsResponse, error) {
+	events := make([]*models.Event, len(req.Events))
+	authorIDs := make([]uint64, len(req.Events))
+	for i, e := range req.Events {
+		events[i] = &models.Event{}
+		events[i].Prepare(e.Title, e.Content, e.AuthorID)
+		authorIDs[i] = uint64(events[i].AuthorID)
+	}
+	unlockAuthors := b.authorLocks.rlock(authorIDs...)
+	defer unlockAuthors()
+
+	created := make([]bool, len(events))
+	statuses, err := b.runBatch(ctx, "Events", len(events), req.BestEffort,
+		func(err error) error { return toStatus(ctx, err, "event") },
+		func(_ storage.UserRepository, repository storage.EventRepository, i int) error {
+			if err := events[i].Validate(); err != nil {
+				return err
+			}
+			_, err := repository.Create(ctx, events[i])
+			created[i] = err == nil
+			return err
+		},
+	)
+	if err != nil {
+		return &pbEvent.BatchAddEventsResponse{}, err
+	}
+	b.wrote(ctx)
 
-	event := models.Event{}
+	ids := make([]uint64, 0, len(events))
+	for i := range events {
+		if created[i] {
+			ids = append(ids, events[i].ID)
+		}
+	}
+	unlock := b.eventLocks.lock(ids...)
+	defer unlock()
+
+	results := make([]*pbEvent.BatchAddEventResult, len(events))
+	for i, st := range statuses {
+		results[i] = &pbEvent.BatchAddEventResult{Status: st}
+		if created[i] {
+			results[i].Event = eventDTO(events[i])
+			b.changes.Publish(pbEvent.EventChange_CREATED, results[i].Event)
+		}
+	}
+	return &pbEvent.BatchAddEventsResponse{Results: results}, nil
+}
 
-	rowAffected, err := event.DeleteAEvent(b.DB, uint64(req.EventId), uint32(req.AuthorId))
+// BatchDeleteEvents deletes several events in the database.
+func (b *Backend) BatchDeleteEvents(ctx context.Context, req *pbEvent.BatchDeleteEventsRequest) (*pbEvent.BatchDeleteEventsResponse, error) {
+	refs := make([]storage.EventRef, len(req.Events))
+	ids := make([]uint64, len(req.Events))
+	for i, e := range req.Events {
+		refs[i] = storage.EventRef{ID: uint64(e.EventId), AuthorID: uint32(e.AuthorId)}
+		ids[i] = refs[i].ID
+	}
+	unlock := b.eventLocks.lock(ids...)
+	defer unlock()
+
+	statuses, err := b.runBatch(ctx, "Events", len(refs), req.BestEffort,
+		func(err error) error { return toStatus(ctx, err, "event") },
+		func(_ storage.UserRepository, repository storage.EventRepository, i int) error {
+			_, err := repository.Delete(ctx, refs[i])
+			return err
+		},
+	)
 	if err != nil {
-		return &pbEvent.DeleteEventRequest{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error unable to delete user: %v", err),
-		)
+		return &pbEvent.BatchDeleteEventsResponse{}, err
 	}
+	b.wrote(ctx)
 
-	return &pbEvent.DeleteEventRequest{
-		EventId:  rowAffected,
-		AuthorId: req.AuthorId,
-	}, nil
+	results := make([]*pbEvent.BatchDeleteEventResult, len(req.Events))
+	for i, st := range statuses {
+		results[i] = &pbEvent.BatchDeleteEventResult{
+			EventId: req.Events[i].EventId,
+			Status:  st,
+		}
+		if codes.Code(st.Code) == codes.OK {
+			b.changes.Publish(pbEvent.EventChange_DELETED, &pbEvent.EventDTO{
+				ID:       req.Events[i].EventId,
+				AuthorID: int32(req.Events[i].AuthorId),
+			})
+		}
+	}
+	return &pbEvent.BatchDeleteEventsResponse{Results: results}, nil
+}
+
+// eventDTO converts an event model to its protobuf representation.
+func eventDTO(e *models.Event) *pbEvent.EventDTO {
+	// Convert timestamp
+	createdAtTimesStamp, _ := ptypes.TimestampProto(e.CreatedAt)
+	updatedAtTimesStamp, _ := ptypes.TimestampProto(e.UpdatedAt)
+
+	return &pbEvent.EventDTO{
+		ID:        int64(e.ID),
+		Title:     e.Title,
+		Content:   e.Content,
+		AuthorID:  int32(e.AuthorID),
+		CreatedAt: createdAtTimesStamp,
+		UpdatedAt: updatedAtTimesStamp,
+	}
 }
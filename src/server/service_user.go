@@ -2,149 +2,159 @@ package server
 
 import (
 	"context"
-	"fmt"
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
 	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
-	"github.com/golang/protobuf/ptypes"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
 )
 
 // AddUser adds a user to the database
 func (b *Backend) AddUser(ctx context.Context, req *pbUser.AddUserRequest) (*pbUser.UserDTO, error) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	user := models.User{}
-
 	user.Prepare(req.Nickname, req.Email, req.Password)
-	err := user.Validate("")
-	if err != nil {
-		return &pbUser.UserDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while creating user in database: %v", err),
-		)
+	if err := user.Validate(); err != nil {
+		return &pbUser.UserDTO{}, toStatus(ctx, err, "user")
 	}
-	userCreated, err := user.SaveUser(b.DB)
+	userCreated, err := b.users.Create(ctx, &user)
 	if err != nil {
-		return &pbUser.UserDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while creating user in database: %v", err),
-		)
+		return &pbUser.UserDTO{}, toStatus(ctx, err, "user")
 	}
-
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(userCreated.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(userCreated.UpdatedAt)
-
-	return &pbUser.UserDTO{
-		ID:        int32(userCreated.ID),
-		Nickname:  userCreated.Nickname,
-		Email:     userCreated.Email,
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
-	}, nil
+	b.wrote(ctx)
+	return userDTO(userCreated), nil
 }
 
 // UpdateUser adds a user to the database
 func (b *Backend) UpdateUser(ctx context.Context, req *pbUser.UpdateUserRequest) (*pbUser.UserDTO, error) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
 	user := models.User{}
-
 	user.Prepare(req.Nickname, req.Email, req.Password)
-	err := user.Validate("update")
-	if err != nil {
-		return &pbUser.UserDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while updating user in database: %v", err),
-		)
+	if err := user.Validate(); err != nil {
+		return &pbUser.UserDTO{}, toStatus(ctx, err, "user")
 	}
-	userUpdated, err := user.UpdateAUser(b.DB, uint32(req.ID))
+	userUpdated, err := b.users.Update(ctx, uint32(req.ID), &user)
 	if err != nil {
-		return &pbUser.UserDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while updating user in database: %v", err),
-		)
+		return &pbUser.UserDTO{}, toStatus(ctx, err, "user")
 	}
-
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(userUpdated.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(userUpdated.UpdatedAt)
-
-	return &pbUser.UserDTO{
-		ID:        int32(userUpdated.ID),
-		Nickname:  userUpdated.Nickname,
-		Email:     userUpdated.Email,
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
-	}, nil
+	b.wrote(ctx)
+	return userDTO(userUpdated), nil
 }
 
 // ListUsers lists all users in the database.
 func (b *Backend) ListUsers(_ *pbUser.ListUsersRequest, srv pbUser.UserService_ListUsersServer) error {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	user := models.User{}
-
-	err := user.FindAllUsers(b.DB, srv)
+	err := b.users.List(b.readContext(srv.Context()), func(u *models.User) error {
+		return srv.Send(userDTO(u))
+	})
 	if err != nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error while streaming users from database: %v", err),
-		)
+		return toStatus(srv.Context(), err, "user")
 	}
 	return nil
 }
 
 // GetUser get one user in the database.
 func (b *Backend) GetUser(ctx context.Context, req *pbUser.GetUserRequest) (*pbUser.UserDTO, error) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	user := models.User{}
+	userResult, err := b.users.Get(b.readContext(ctx), uint32(req.UserId))
+	if err != nil {
+		return &pbUser.UserDTO{}, toStatus(ctx, err, "user")
+	}
+	return userDTO(userResult), nil
+}
 
-	userResult, err := user.FindUserByID(b.DB, uint32(req.UserId))
+// DeleteUser delete one user, and the events they authored, in the database.
+func (b *Backend) DeleteUser(ctx context.Context, req *pbUser.DeleteUserRequest) (*pbUser.DeleteUserRequest, error) {
+	// No event of the user is created meanwhile, events never changing
+	// author: those listed now are the ones deleted along, or fewer. They are
+	// locked before the unit of work runs, writers to them holding their lock
+	// while waiting on the storage, and are not written to until their
+	// deletion is published.
+	unlockAuthor := b.authorLocks.lock(uint64(req.UserId))
+	defer unlockAuthor()
+	var ids []uint64
+	err := b.events.ListByAuthor(storage.ReadPrimary(ctx), uint32(req.UserId), func(e *models.Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
 	if err != nil {
-		return &pbUser.UserDTO{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error unable to get user: %v", err),
-		)
+		return &pbUser.DeleteUserRequest{}, toStatus(ctx, err, "user")
 	}
+	unlock := b.eventLocks.lock(ids...)
+	defer unlock()
+
+	var rowAffected int64
+	var deleted []*pbEvent.EventDTO
+	err = b.work.Do(ctx, func(users storage.UserRepository, events storage.EventRepository) error {
+		deleted = deleted[:0]
+		_, err := events.DeleteByAuthor(ctx, uint32(req.UserId), func(e *models.Event) error {
+			deleted = append(deleted, &pbEvent.EventDTO{ID: int64(e.ID), AuthorID: int32(e.AuthorID)})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		rowAffected, err = users.Delete(ctx, uint32(req.UserId))
+		return err
+	})
+	if err != nil {
+		return &pbUser.DeleteUserRequest{}, toStatus(ctx, err, "user")
+	}
+	b.wrote(ctx)
 
-	// Convert timestamp
-	createdAtTimesStamp, err := ptypes.TimestampProto(userResult.CreatedAt)
-	updatedAtTimesStamp, err := ptypes.TimestampProto(userResult.UpdatedAt)
+	for _, e := range deleted {
+		b.changes.Publish(pbEvent.EventChange_DELETED, e)
+	}
 
-	return &pbUser.UserDTO{
-		ID:        int32(userResult.ID),
-		Nickname:  userResult.Nickname,
-		Email:     userResult.Email,
-		CreatedAt: createdAtTimesStamp,
-		UpdatedAt: updatedAtTimesStamp,
+	return &pbUser.DeleteUserRequest{
+		UserId: int32(rowAffected),
 	}, nil
 }
 
-// DeleteUser delete one user in the database.
-func (b *Backend) DeleteUser(ctx context.Context, req *pbUser.DeleteUserRequest) (*pbUser.DeleteUserRequest, error) {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	user := models.User{}
+// BatchAddUsers adds several users to the database.
+func (b *Backend) BatchAddUsers(ctx context.Context, req *pbUser.BatchAddUsersRequest) (*pbUser.BatchAddUsersResponse, error) {
+	users := make([]*models.User, len(req.Users))
+	for i, u := range req.Users {
+		users[i] = &models.User{}
+		users[i].Prepare(u.Nickname, u.Email, u.Password)
+	}
 
-	rowAffected, err := user.DeleteAUser(b.DB, uint32(req.UserId))
+	created := make([]bool, len(users))
+	statuses, err := b.runBatch(ctx, "Users", len(users), req.BestEffort,
+		func(err error) error { return toStatus(ctx, err, "user") },
+		func(repository storage.UserRepository, _ storage.EventRepository, i int) error {
+			if err := users[i].Validate(); err != nil {
+				return err
+			}
+			_, err := repository.Create(ctx, users[i])
+			created[i] = err == nil
+			return err
+		},
+	)
 	if err != nil {
-		return &pbUser.DeleteUserRequest{}, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Error unable to delete user: %v", err),
-		)
+		return &pbUser.BatchAddUsersResponse{}, err
 	}
+	b.wrote(ctx)
+
+	results := make([]*pbUser.BatchAddUserResult, len(users))
+	for i, st := range statuses {
+		results[i] = &pbUser.BatchAddUserResult{Status: st}
+		if created[i] {
+			results[i].User = userDTO(users[i])
+		}
+	}
+	return &pbUser.BatchAddUsersResponse{Results: results}, nil
+}
 
-	return &pbUser.DeleteUserRequest{
-		UserId: int32(rowAffected),
-	}, nil
+// userDTO converts a user model to its protobuf representation.
+func userDTO(u *models.User) *pbUser.UserDTO {
+	// Convert timestamp
+	createdAtTimesStamp, _ := ptypes.TimestampProto(u.CreatedAt)
+	updatedAtTimesStamp, _ := ptypes.TimestampProto(u.UpdatedAt)
+
+	return &pbUser.UserDTO{
+		ID:        int32(u.ID),
+		Nickname:  u.Nickname,
+		Email:     u.Email,
+		CreatedAt: createdAtTimesStamp,
+		UpdatedAt: updatedAtTimesStamp,
+	}
 }
@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/cache"
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+// Results of the cached lookups told to Cached.Observe.
+const (
+	CacheHit    = "hit"
+	CacheMiss   = "miss"
+	CacheBypass = "bypass"
+)
+
+// Cached puts a cache in front of the Get of the repositories it wraps. The
+// writes made through them, units of work included, drop the records they
+// change from the cache. Reads of contexts reading from the primary, see
+// ReadPrimary, bypass the cache.
+//
+// A read racing with a write does not cache the record as it was before the
+// write: writes count the times they dropped each key, reads caching what
+// they read unless the count changed meanwhile. The counts are kept by each
+// Cached, so a cache shared between instances sees the records read racing
+// with the writes of other instances cached until their ttl passes, and so
+// are those read from a replica behind the primary.
+type Cached struct {
+	// generations counts the writes to the keys of each stripe, first for
+	// its 64-bit atomic operations to be aligned
+	generations [generationStripes]uint64
+	cache       cache.Cache
+	ttl         time.Duration
+
+	// Observe, when not nil, is called with the table, "users" or "events",
+	// and the result of every cached Get.
+	Observe func(table, result string)
+}
+
+// generationStripes is the number of write counts the keys share.
+const generationStripes = 256
+
+// NewCached returns a Cached keeping records in c for ttl.
+func NewCached(c cache.Cache, ttl time.Duration) *Cached {
+	return &Cached{cache: c, ttl: ttl}
+}
+
+// Users returns r with a cache in front of its Get. Its Delete drops the
+// user only: delete their events first with DeleteByAuthor for those to be
+// dropped too, rather than along with the user.
+//
+// The password hashes are not cached: its Get returns users without their
+// Password, whether cached or not. FindByEmail and the repositories of units
+// of work still return it.
+func (c *Cached) Users(r UserRepository) UserRepository {
+	return &cachedUsers{UserRepository: r, cached: c}
+}
+
+// Events returns r with a cache in front of its Get.
+func (c *Cached) Events(r EventRepository) EventRepository {
+	return &cachedEvents{EventRepository: r, cached: c}
+}
+
+// UnitOfWork returns w dropping the records written by its units of work from
+// the cache once they end.
+func (c *Cached) UnitOfWork(w UnitOfWork) UnitOfWork {
+	return &cachedUnitOfWork{work: w, cached: c}
+}
+
+func userKey(id uint32) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+func eventKey(id uint64) string {
+	return fmt.Sprintf("event:%d", id)
+}
+
+func (c *Cached) observe(table, result string) {
+	if c.Observe != nil {
+		c.Observe(table, result)
+	}
+}
+
+// get decodes the value of key into v, and returns false when it is missing or
+// the cache fails.
+func (c *Cached) get(ctx context.Context, key string, v interface{}) bool {
+	value, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Cache failed, reading from the database", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return ok && json.Unmarshal(value, v) == nil
+}
+
+// generation returns the write count of key.
+func (c *Cached) generation(key string) *uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &c.generations[h.Sum32()%generationStripes]
+}
+
+// fill caches v, read from the database when the write count of key was gen,
+// unless a write dropped key since. Such a write may drop key before v is
+// cached: the count is checked again, dropping v if it changed.
+func (c *Cached) fill(ctx context.Context, key string, gen uint64, v interface{}) {
+	count := c.generation(key)
+	if atomic.LoadUint64(count) != gen {
+		return
+	}
+	c.set(ctx, key, v)
+	if atomic.LoadUint64(count) != gen {
+		c.invalidate(ctx, []string{key})
+	}
+}
+
+func (c *Cached) set(ctx context.Context, key string, v interface{}) {
+	value, err := json.Marshal(v)
+	if err == nil {
+		err = c.cache.Set(ctx, key, value, c.ttl)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn("Cannot cache the record", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// invalidate drops keys from the cache, counting the write first for the
+// reads racing with it not to cache what they read.
+func (c *Cached) invalidate(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		atomic.AddUint64(c.generation(key), 1)
+	}
+	if err := c.cache.Delete(ctx, keys...); err != nil {
+		logging.FromContext(ctx).Error("Cannot drop the written records from the cache", zap.Strings("keys", keys), zap.Error(err))
+	}
+}
+
+// writes collects the records written in a unit of work, dropped from the
+// cache once it ends. A nil writes drops them right away.
+type writes struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+// wrote drops keys once w ends. Writes drop their records whatever their
+// outcome: one failing with its context may still have been committed.
+func (c *Cached) wrote(ctx context.Context, w *writes, keys ...string) {
+	if w == nil {
+		c.invalidate(ctx, keys)
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.keys = append(w.keys, keys...)
+}
+
+type cachedUsers struct {
+	UserRepository
+	cached *Cached
+	// writes is set within a unit of work, whose reads are not cached
+	writes *writes
+}
+
+func (r *cachedUsers) Get(ctx context.Context, id uint32) (*models.User, error) {
+	if r.writes != nil {
+		return r.UserRepository.Get(ctx, id)
+	}
+	if readsPrimary(ctx) {
+		r.cached.observe("users", CacheBypass)
+	} else {
+		u := &models.User{}
+		if r.cached.get(ctx, userKey(id), u) {
+			r.cached.observe("users", CacheHit)
+			return u, nil
+		}
+		r.cached.observe("users", CacheMiss)
+	}
+
+	gen := atomic.LoadUint64(r.cached.generation(userKey(id)))
+	u, err := r.UserRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	u.Password = ""
+	r.cached.fill(ctx, userKey(id), gen, u)
+	return u, nil
+}
+
+func (r *cachedUsers) Update(ctx context.Context, id uint32, u *models.User) (*models.User, error) {
+	defer r.cached.wrote(ctx, r.writes, userKey(id))
+	return r.UserRepository.Update(ctx, id, u)
+}
+
+func (r *cachedUsers) Delete(ctx context.Context, id uint32) (int64, error) {
+	defer r.cached.wrote(ctx, r.writes, userKey(id))
+	return r.UserRepository.Delete(ctx, id)
+}
+
+type cachedEvents struct {
+	EventRepository
+	cached *Cached
+	// writes is set within a unit of work, whose reads are not cached
+	writes *writes
+}
+
+func (r *cachedEvents) Get(ctx context.Context, id uint64) (*models.Event, error) {
+	if r.writes != nil {
+		return r.EventRepository.Get(ctx, id)
+	}
+	if readsPrimary(ctx) {
+		r.cached.observe("events", CacheBypass)
+	} else {
+		e := &models.Event{}
+		if r.cached.get(ctx, eventKey(id), e) {
+			r.cached.observe("events", CacheHit)
+			return e, nil
+		}
+		r.cached.observe("events", CacheMiss)
+	}
+
+	gen := atomic.LoadUint64(r.cached.generation(eventKey(id)))
+	e, err := r.EventRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.cached.fill(ctx, eventKey(id), gen, e)
+	return e, nil
+}
+
+func (r *cachedEvents) Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error) {
+	defer r.cached.wrote(ctx, r.writes, eventKey(id))
+	return r.EventRepository.Update(ctx, id, e)
+}
+
+func (r *cachedEvents) Delete(ctx context.Context, ref EventRef) (int64, error) {
+	defer r.cached.wrote(ctx, r.writes, eventKey(ref.ID))
+	return r.EventRepository.Delete(ctx, ref)
+}
+
+func (r *cachedEvents) DeleteByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) (int64, error) {
+	var keys []string
+	defer func() { r.cached.wrote(ctx, r.writes, keys...) }()
+	return r.EventRepository.DeleteByAuthor(ctx, authorID, func(e *models.Event) error {
+		keys = append(keys, eventKey(e.ID))
+		return fn(e)
+	})
+}
+
+type cachedUnitOfWork struct {
+	work   UnitOfWork
+	cached *Cached
+}
+
+func (w *cachedUnitOfWork) Do(ctx context.Context, fn func(users UserRepository, events EventRepository) error) error {
+	written := &writes{}
+	// Dropped whatever the outcome, like the writes of the repositories
+	defer func() { w.cached.invalidate(ctx, written.keys) }()
+	return w.work.Do(ctx, func(users UserRepository, events EventRepository) error {
+		return fn(
+			&cachedUsers{UserRepository: users, cached: w.cached, writes: written},
+			&cachedEvents{EventRepository: events, cached: w.cached, writes: written},
+		)
+	})
+}
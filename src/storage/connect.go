@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Backoff between the attempts of Connect, doubled after each one.
+const (
+	initialBackoff = 250 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// Pool configures the connections database/sql keeps to a database. Zero
+// values keep the defaults of database/sql: unlimited open connections, 2 idle
+// ones, and connections reused forever.
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+func (p Pool) apply(db *gorm.DB) {
+	if p.MaxOpenConns > 0 {
+		db.DB().SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.DB().SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		db.DB().SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+}
+
+// Connect opens the database like Open, retrying while it does not accept
+// connections, as when it starts along with the server, with a backoff
+// doubling from 250ms to 5s between attempts. It gives up with the last error
+// when ctx is done. retry, when not nil, is called with the error of every
+// failed attempt and the time waited before the next one. Settings that cannot
+// work, such as an unknown driver, fail at once.
+func Connect(ctx context.Context, driver, user, password, port, host, name string, pool Pool, retry func(err error, wait time.Duration)) (*gorm.DB, error) {
+	if _, err := dsn(driver, user, password, port, host, name); err != nil {
+		return nil, err
+	}
+
+	wait := initialBackoff
+	for {
+		db, err := Open(driver, user, password, port, host, name, pool)
+		if err == nil {
+			return db, nil
+		}
+		if retry != nil {
+			retry(err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%v, giving up: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+		if wait *= 2; wait > maxBackoff {
+			wait = maxBackoff
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+
+	_ "github.com/jinzhu/gorm/dialects/mysql"    //mysql database driver
+	_ "github.com/jinzhu/gorm/dialects/postgres" //postgres database driver
+	_ "github.com/jinzhu/gorm/dialects/sqlite"   //sqlite database driver
+)
+
+// Database drivers supported by Open.
+const (
+	PostgresDriver = "postgres"
+	MySQLDriver    = "mysql"
+	SQLiteDriver   = "sqlite3"
+)
+
+// SQLiteMemory is the database name keeping a SQLite database in memory.
+const SQLiteMemory = ":memory:"
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+	pqQueryCanceled       = "57014"
+)
+
+// MySQL error numbers, see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlDuplicateEntry     = 1062
+	mysqlNoReferencedRow    = 1216
+	mysqlNoReferencedRowTwo = 1452
+)
+
+// ValidDriver reports whether driver names a supported storage.
+func ValidDriver(driver string) bool {
+	switch driver {
+	case PostgresDriver, MySQLDriver, SQLiteDriver, MemoryDriver:
+		return true
+	}
+	return false
+}
+
+// dsn returns the data source name connecting driver to the database. The
+// name of a SQLite database is the path of its file, or SQLiteMemory.
+func dsn(driver, user, password, port, host, name string) (string, error) {
+	switch driver {
+	case PostgresDriver:
+		return fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s", host, port, user, name, password), nil
+	case MySQLDriver:
+		cfg := mysql.NewConfig()
+		cfg.User = user
+		cfg.Passwd = password
+		cfg.Net = "tcp"
+		cfg.Addr = net.JoinHostPort(host, port)
+		cfg.DBName = name
+		// gorm scans DATETIME columns into time.Time
+		cfg.ParseTime = true
+		cfg.Params = map[string]string{"charset": "utf8mb4"}
+		return cfg.FormatDSN(), nil
+	case SQLiteDriver:
+		if name == "" {
+			return "", errors.New("a SQLite database file, or :memory:, is required as the database name")
+		}
+		// Foreign keys are only enforced when enabled, and writers wait for
+		// each other rather than failing with "database is locked". Reading
+		// transactions could not wait to upgrade to writing ones, so
+		// transactions take the write lock when they begin: reads run outside
+		// of one, see readStatement.
+		params := url.Values{"_foreign_keys": {"1"}, "_busy_timeout": {"5000"}, "_txlock": {"immediate"}}
+		return name + "?" + params.Encode(), nil
+	}
+	return "", fmt.Errorf("unsupported database driver %q, use %s, %s, %s or %s", driver, PostgresDriver, MySQLDriver, SQLiteDriver, MemoryDriver)
+}
+
+// statementTimeout bounds the statements of tx by the deadline of ctx, so that
+// the database cancels those still running when it passes. Only Postgres can
+// set a timeout for one transaction; on other databases a running statement
+// completes, the statements following it failing.
+func statementTimeout(ctx context.Context, tx *gorm.DB) error {
+	deadline, ok := ctx.Deadline()
+	if !ok || tx.Dialect().GetName() != PostgresDriver {
+		return nil
+	}
+	ms := time.Until(deadline).Milliseconds()
+	if ms < 1 {
+		return context.DeadlineExceeded
+	}
+	return tx.Exec(fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)).Error
+}
+
+// translate converts the errors of gorm and of the database drivers into the
+// errors of this package.
+func translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrNotFound
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return ErrAlreadyExists
+		case pqForeignKeyViolation:
+			return ErrMissingReference
+		case pqQueryCanceled:
+			// Canceled by statementTimeout
+			return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+		}
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlDuplicateEntry:
+			return ErrAlreadyExists
+		case mysqlNoReferencedRow, mysqlNoReferencedRowTwo:
+			return ErrMissingReference
+		}
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return ErrAlreadyExists
+		case sqlite3.ErrConstraintForeignKey:
+			return ErrMissingReference
+		}
+	}
+	return err
+}
@@ -0,0 +1,391 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tracing"
+)
+
+// Open connects to the database of driver, one of PostgresDriver,
+// MySQLDriver and SQLiteDriver, with the connections of pool. Its tables are
+// created by the migrations of the migrate package.
+func Open(driver, user, password, port, host, name string, pool Pool) (*gorm.DB, error) {
+	source, err := dsn(driver, user, password, port, host, name)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(driver, source)
+	if err != nil {
+		return nil, err
+	}
+	if driver == SQLiteDriver && name == SQLiteMemory {
+		// Every connection would open a database of its own, and closing the
+		// only one would lose it
+		pool = Pool{MaxOpenConns: 1, MaxIdleConns: 1}
+	}
+	pool.apply(db)
+
+	// gorm prints errors and, in debug mode, statements with their values;
+	// queries are logged by logging.InstrumentGorm instead.
+	db.SetLogger(logging.GormLogger())
+	db.LogMode(false)
+	return db, nil
+}
+
+// withContext returns db carrying ctx, so that its queries are traced and
+// logged as part of the request of ctx.
+func withContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return logging.WithContext(ctx, tracing.WithContext(ctx, db))
+}
+
+// transaction runs fn in a transaction, committed unless fn fails. Writes
+// are not serialized by the server: each one is a single statement or runs in
+// a transaction of its own, at the default isolation level of the database
+// (read committed for Postgres, repeatable read for MySQL, serializable for
+// SQLite), and the constraints of the tables settle concurrent conflicts.
+// When db is already a transaction, fn runs as part of it.
+//
+// gorm only passes contexts to the database through transactions, so reads
+// run in one too: waiting for a connection of the pool stops when ctx is done,
+// which rolls the transaction back, and statements still running when the
+// deadline of ctx passes are canceled on Postgres, see statementTimeout.
+func transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if _, ok := db.CommonDB().(*sql.Tx); ok {
+		return fn(withContext(ctx, db))
+	}
+
+	tx, err := begin(ctx, db)
+	if err != nil {
+		return err
+	}
+	return end(ctx, tx, fn(tx))
+}
+
+// readStatement runs fn, a read made of a single statement, on db like
+// transaction. SQLite transactions take the write lock when they begin, see
+// dsn, so the statement runs outside of one there, holding the read lock only.
+// It is not bound to ctx then, which is only checked first.
+func readStatement(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if _, ok := db.CommonDB().(*sql.Tx); ok || db.Dialect().GetName() != SQLiteDriver {
+		return transaction(ctx, db, fn)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(withContext(ctx, db))
+}
+
+// begin starts a transaction of db bound to ctx.
+func begin(ctx context.Context, db *gorm.DB) (*gorm.DB, error) {
+	tx := withContext(ctx, db).BeginTx(ctx, nil)
+	if tx.Error != nil {
+		return nil, translate(tx.Error)
+	}
+	if err := statementTimeout(ctx, tx); err != nil {
+		tx.Rollback()
+		return nil, translate(err)
+	}
+	return tx, nil
+}
+
+// end commits tx unless err, the error of its statements, is not nil or ctx
+// is done, rolling it back then.
+func end(ctx context.Context, tx *gorm.DB, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		// Statements fail once the transaction is rolled back, report why
+		err = ctxErr
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return translate(tx.Commit().Error)
+}
+
+// GormUnitOfWork runs units of work in transactions of a database.
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewGormUnitOfWork returns a UnitOfWork backed by db.
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do implements UnitOfWork.
+func (w *GormUnitOfWork) Do(ctx context.Context, fn func(users UserRepository, events EventRepository) error) error {
+	return transaction(ctx, w.db, func(tx *gorm.DB) error {
+		return fn(NewGormUserRepository(tx), NewGormEventRepository(tx))
+	})
+}
+
+// GormUserRepository stores users in the users table.
+type GormUserRepository struct {
+	db       *gorm.DB
+	replicas *replicas
+}
+
+// NewGormUserRepository returns a UserRepository backed by db. Its reads go
+// to the read replicas of db, if any, see ReadPrimary.
+func NewGormUserRepository(db *gorm.DB, replicas ...*gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db: db, replicas: newReplicas(replicas)}
+}
+
+// Create implements UserRepository.
+func (r *GormUserRepository) Create(ctx context.Context, u *models.User) (*models.User, error) {
+	// The BeforeSave hook of the model hashes the password
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		return translate(tx.Create(u).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Get implements UserRepository.
+func (r *GormUserRepository) Get(ctx context.Context, id uint32) (*models.User, error) {
+	u := &models.User{}
+	err := r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		return translate(tx.Where("id = ?", id).Take(u).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// FindByEmail implements UserRepository.
+func (r *GormUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	u := &models.User{}
+	err := r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		return translate(tx.Where("email = ?", email).Take(u).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// List implements UserRepository.
+func (r *GormUserRepository) List(ctx context.Context, fn func(*models.User) error) error {
+	return r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		rows, err := tx.Model(&models.User{}).Order("id").Rows()
+		if err != nil {
+			return translate(err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			u := &models.User{}
+			if err := tx.ScanRows(rows, u); err != nil {
+				return translate(err)
+			}
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+		return translate(rows.Err())
+	})
+}
+
+// Update implements UserRepository.
+func (r *GormUserRepository) Update(ctx context.Context, id uint32, u *models.User) (*models.User, error) {
+	// UpdateColumns skips the hooks, hash the password here
+	if err := u.BeforeSave(); err != nil {
+		return nil, err
+	}
+
+	// Read back in the transaction, which holds the lock of the updated row
+	updated := &models.User{}
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", id).UpdateColumns(
+			map[string]interface{}{
+				"password":   u.Password,
+				"nickname":   u.Nickname,
+				"email":      u.Email,
+				"updated_at": time.Now(),
+			},
+		).Error
+		if err != nil {
+			return translate(err)
+		}
+		return translate(tx.Where("id = ?", id).Take(updated).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete implements UserRepository.
+func (r *GormUserRepository) Delete(ctx context.Context, id uint32) (int64, error) {
+	// One statement, the foreign key of the events deletes them along
+	var deleted int64
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		tx = tx.Where("id = ?", id).Delete(&models.User{})
+		deleted = tx.RowsAffected
+		return translate(tx.Error)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if deleted == 0 {
+		return 0, ErrNotFound
+	}
+	return deleted, nil
+}
+
+// GormEventRepository stores events in the events table.
+type GormEventRepository struct {
+	db       *gorm.DB
+	replicas *replicas
+}
+
+// NewGormEventRepository returns an EventRepository backed by db. Its reads go
+// to the read replicas of db, if any, see ReadPrimary.
+func NewGormEventRepository(db *gorm.DB, replicas ...*gorm.DB) *GormEventRepository {
+	return &GormEventRepository{db: db, replicas: newReplicas(replicas)}
+}
+
+// Create implements EventRepository.
+func (r *GormEventRepository) Create(ctx context.Context, e *models.Event) (*models.Event, error) {
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		// Events tables created before the migrations may lack their foreign key
+		err := tx.Where("id = ?", e.AuthorID).Take(&models.User{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return ErrMissingReference
+		}
+		if err != nil {
+			return translate(err)
+		}
+		return translate(tx.Create(e).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Get implements EventRepository.
+func (r *GormEventRepository) Get(ctx context.Context, id uint64) (*models.Event, error) {
+	e := &models.Event{}
+	err := r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		return translate(tx.Where("id = ?", id).Take(e).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FindByTitle implements EventRepository.
+func (r *GormEventRepository) FindByTitle(ctx context.Context, title string) (*models.Event, error) {
+	e := &models.Event{}
+	err := r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		return translate(tx.Where("title = ?", title).Take(e).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// List implements EventRepository.
+func (r *GormEventRepository) List(ctx context.Context, fn func(*models.Event) error) error {
+	return r.list(ctx, fn)
+}
+
+// ListByAuthor implements EventRepository.
+func (r *GormEventRepository) ListByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) error {
+	return r.list(ctx, fn, "author_id = ?", authorID)
+}
+
+// list calls fn with the events matching the where conditions, if any.
+func (r *GormEventRepository) list(ctx context.Context, fn func(*models.Event) error, where ...interface{}) error {
+	return r.replicas.read(ctx, r.db, func(tx *gorm.DB) error {
+		return scanEvents(tx, fn, where...)
+	})
+}
+
+// scanEvents calls fn with the events of tx matching the where conditions, if
+// any, in ID order.
+func scanEvents(tx *gorm.DB, fn func(*models.Event) error, where ...interface{}) error {
+	query := tx.Model(&models.Event{})
+	if len(where) > 0 {
+		query = query.Where(where[0], where[1:]...)
+	}
+	rows, err := query.Order("id").Rows()
+	if err != nil {
+		return translate(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := &models.Event{}
+		if err := tx.ScanRows(rows, e); err != nil {
+			return translate(err)
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return translate(rows.Err())
+}
+
+// Update implements EventRepository.
+func (r *GormEventRepository) Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error) {
+	updated := &models.Event{}
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		err := tx.Model(&models.Event{}).Where("id = ?", id).
+			Updates(models.Event{Title: e.Title, Content: e.Content, UpdatedAt: time.Now()}).Error
+		if err != nil {
+			return translate(err)
+		}
+		return translate(tx.Where("id = ?", id).Take(updated).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete implements EventRepository.
+func (r *GormEventRepository) Delete(ctx context.Context, ref EventRef) (int64, error) {
+	var deleted int64
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		tx = tx.Where("id = ? and author_id = ?", ref.ID, ref.AuthorID).Delete(&models.Event{})
+		deleted = tx.RowsAffected
+		return translate(tx.Error)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if deleted == 0 {
+		return 0, ErrNotFound
+	}
+	return deleted, nil
+}
+
+// DeleteByAuthor implements EventRepository.
+func (r *GormEventRepository) DeleteByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) (int64, error) {
+	var deleted int64
+	err := transaction(ctx, r.db, func(tx *gorm.DB) error {
+		if err := scanEvents(tx, fn, "author_id = ?", authorID); err != nil {
+			return err
+		}
+		tx = tx.Where("author_id = ?", authorID).Delete(&models.Event{})
+		deleted = tx.RowsAffected
+		return translate(tx.Error)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
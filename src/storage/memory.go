@@ -0,0 +1,407 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+// MemoryDriver is the database driver selecting the in-memory storage.
+const MemoryDriver = "memory"
+
+// Memory keeps users and events in process memory, enforcing the constraints
+// of the database tables: unique nicknames, emails and event titles, IDs
+// incremented on every insert, events referencing an existing author and
+// deleted along with it. Records are lost on restart and are not shared
+// between instances.
+type Memory struct {
+	mu          sync.RWMutex
+	users       map[uint32]models.User
+	events      map[uint64]models.Event
+	lastUserID  uint32
+	lastEventID uint64
+}
+
+// NewMemory returns an empty Memory.
+func NewMemory() *Memory {
+	return &Memory{
+		users:  make(map[uint32]models.User),
+		events: make(map[uint64]models.Event),
+	}
+}
+
+// PingContext implements healthcheck.Pinger, memory is always reachable.
+func (m *Memory) PingContext(ctx context.Context) error {
+	return nil
+}
+
+// MemoryUnitOfWork runs units of work on a copy of a Memory, replacing it
+// once they succeed. Units of work and writes are serialized, and each copies
+// every record, which suits the development and test use of Memory.
+type MemoryUnitOfWork struct {
+	m *Memory
+}
+
+// NewMemoryUnitOfWork returns a UnitOfWork backed by m.
+func NewMemoryUnitOfWork(m *Memory) *MemoryUnitOfWork {
+	return &MemoryUnitOfWork{m: m}
+}
+
+// Do implements UnitOfWork.
+func (w *MemoryUnitOfWork) Do(ctx context.Context, fn func(users UserRepository, events EventRepository) error) error {
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+
+	work := &Memory{
+		users:       make(map[uint32]models.User, len(w.m.users)),
+		events:      make(map[uint64]models.Event, len(w.m.events)),
+		lastUserID:  w.m.lastUserID,
+		lastEventID: w.m.lastEventID,
+	}
+	for id, u := range w.m.users {
+		work.users[id] = u
+	}
+	for id, e := range w.m.events {
+		work.events[id] = e
+	}
+
+	err := fn(NewMemoryUserRepository(work), NewMemoryEventRepository(work))
+	if err == nil {
+		err = ctx.Err()
+	}
+	// IDs are not reused, as with database sequences
+	w.m.lastUserID = work.lastUserID
+	w.m.lastEventID = work.lastEventID
+	if err != nil {
+		return err
+	}
+	w.m.users = work.users
+	w.m.events = work.events
+	return nil
+}
+
+// MemoryUserRepository stores users in a Memory.
+type MemoryUserRepository struct {
+	m *Memory
+}
+
+// NewMemoryUserRepository returns a UserRepository backed by m.
+func NewMemoryUserRepository(m *Memory) *MemoryUserRepository {
+	return &MemoryUserRepository{m: m}
+}
+
+// Create implements UserRepository.
+func (r *MemoryUserRepository) Create(ctx context.Context, u *models.User) (*models.User, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if err := r.m.createUser(u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (m *Memory) createUser(u *models.User) error {
+	if u.ID != 0 {
+		if _, ok := m.users[u.ID]; ok {
+			return ErrAlreadyExists
+		}
+	}
+	if m.userConflicts(u, 0) {
+		return ErrAlreadyExists
+	}
+	// Hashed as the BeforeSave hook of the model does for gorm
+	if err := u.BeforeSave(); err != nil {
+		return err
+	}
+
+	if u.ID == 0 {
+		m.lastUserID++
+		u.ID = m.lastUserID
+	} else if u.ID > m.lastUserID {
+		m.lastUserID = u.ID
+	}
+	now := time.Now()
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = now
+	}
+	if u.UpdatedAt.IsZero() {
+		u.UpdatedAt = now
+	}
+	m.users[u.ID] = *u
+	return nil
+}
+
+// userConflicts reports whether another user than the one with ID id has the
+// nickname or the email of u.
+func (m *Memory) userConflicts(u *models.User, id uint32) bool {
+	for _, other := range m.users {
+		if other.ID != id && (other.Nickname == u.Nickname || other.Email == u.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get implements UserRepository.
+func (r *MemoryUserRepository) Get(ctx context.Context, id uint32) (*models.User, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	u, ok := r.m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &u, nil
+}
+
+// FindByEmail implements UserRepository.
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	for _, u := range r.m.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// List implements UserRepository.
+func (r *MemoryUserRepository) List(ctx context.Context, fn func(*models.User) error) error {
+	// fn runs on a snapshot, without holding the lock
+	r.m.mu.RLock()
+	users := make([]models.User, 0, len(r.m.users))
+	for _, u := range r.m.users {
+		users = append(users, u)
+	}
+	r.m.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	for i := range users {
+		if err := fn(&users[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update implements UserRepository.
+func (r *MemoryUserRepository) Update(ctx context.Context, id uint32, u *models.User) (*models.User, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	updated, ok := r.m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if r.m.userConflicts(u, id) {
+		return nil, ErrAlreadyExists
+	}
+	if err := u.BeforeSave(); err != nil {
+		return nil, err
+	}
+
+	updated.Nickname = u.Nickname
+	updated.Email = u.Email
+	updated.Password = u.Password
+	updated.UpdatedAt = time.Now()
+	r.m.users[id] = updated
+	return &updated, nil
+}
+
+// Delete implements UserRepository. The events of the user are deleted too.
+func (r *MemoryUserRepository) Delete(ctx context.Context, id uint32) (int64, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if _, ok := r.m.users[id]; !ok {
+		return 0, ErrNotFound
+	}
+	delete(r.m.users, id)
+	for eventID, e := range r.m.events {
+		if e.AuthorID == id {
+			delete(r.m.events, eventID)
+		}
+	}
+	return 1, nil
+}
+
+// MemoryEventRepository stores events in a Memory.
+type MemoryEventRepository struct {
+	m *Memory
+}
+
+// NewMemoryEventRepository returns an EventRepository backed by m.
+func NewMemoryEventRepository(m *Memory) *MemoryEventRepository {
+	return &MemoryEventRepository{m: m}
+}
+
+// Create implements EventRepository.
+func (r *MemoryEventRepository) Create(ctx context.Context, e *models.Event) (*models.Event, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	if err := r.m.createEvent(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (m *Memory) createEvent(e *models.Event) error {
+	if _, ok := m.users[e.AuthorID]; !ok {
+		return ErrMissingReference
+	}
+	if e.ID != 0 {
+		if _, ok := m.events[e.ID]; ok {
+			return ErrAlreadyExists
+		}
+	}
+	if m.eventConflicts(e.Title, 0) {
+		return ErrAlreadyExists
+	}
+
+	if e.ID == 0 {
+		m.lastEventID++
+		e.ID = m.lastEventID
+	} else if e.ID > m.lastEventID {
+		m.lastEventID = e.ID
+	}
+	now := time.Now()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = now
+	}
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = now
+	}
+	stored := *e
+	stored.Author = models.User{}
+	m.events[e.ID] = stored
+	return nil
+}
+
+// eventConflicts reports whether another event than the one with ID id has
+// the given title.
+func (m *Memory) eventConflicts(title string, id uint64) bool {
+	for _, other := range m.events {
+		if other.ID != id && other.Title == title {
+			return true
+		}
+	}
+	return false
+}
+
+// Get implements EventRepository.
+func (r *MemoryEventRepository) Get(ctx context.Context, id uint64) (*models.Event, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	e, ok := r.m.events[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &e, nil
+}
+
+// FindByTitle implements EventRepository.
+func (r *MemoryEventRepository) FindByTitle(ctx context.Context, title string) (*models.Event, error) {
+	r.m.mu.RLock()
+	defer r.m.mu.RUnlock()
+
+	for _, e := range r.m.events {
+		if e.Title == title {
+			return &e, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// List implements EventRepository.
+func (r *MemoryEventRepository) List(ctx context.Context, fn func(*models.Event) error) error {
+	return r.list(fn, func(models.Event) bool { return true })
+}
+
+// ListByAuthor implements EventRepository.
+func (r *MemoryEventRepository) ListByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) error {
+	return r.list(fn, func(e models.Event) bool { return e.AuthorID == authorID })
+}
+
+// list calls fn with the events matching match.
+func (r *MemoryEventRepository) list(fn func(*models.Event) error, match func(models.Event) bool) error {
+	r.m.mu.RLock()
+	events := make([]models.Event, 0, len(r.m.events))
+	for _, e := range r.m.events {
+		if match(e) {
+			events = append(events, e)
+		}
+	}
+	r.m.mu.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	for i := range events {
+		if err := fn(&events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update implements EventRepository. As with gorm, empty fields of e are left
+// unchanged.
+func (r *MemoryEventRepository) Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	updated, ok := r.m.events[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if e.Title != "" {
+		if r.m.eventConflicts(e.Title, id) {
+			return nil, ErrAlreadyExists
+		}
+		updated.Title = e.Title
+	}
+	if e.Content != "" {
+		updated.Content = e.Content
+	}
+	updated.UpdatedAt = time.Now()
+	r.m.events[id] = updated
+	return &updated, nil
+}
+
+// Delete implements EventRepository.
+func (r *MemoryEventRepository) Delete(ctx context.Context, ref EventRef) (int64, error) {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	e, ok := r.m.events[ref.ID]
+	if !ok || e.AuthorID != ref.AuthorID {
+		return 0, ErrNotFound
+	}
+	delete(r.m.events, ref.ID)
+	return 1, nil
+}
+
+// DeleteByAuthor implements EventRepository.
+func (r *MemoryEventRepository) DeleteByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) (int64, error) {
+	if err := r.ListByAuthor(ctx, authorID, fn); err != nil {
+		return 0, err
+	}
+
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+
+	var deleted int64
+	for id, e := range r.m.events {
+		if e.AuthorID == authorID {
+			delete(r.m.events, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
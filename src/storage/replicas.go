@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+)
+
+// primaryKey marks the contexts whose reads go to the primary.
+type primaryKey struct{}
+
+// ReadPrimary returns a context whose reads go to the primary database rather
+// than to a read replica or a cache, see Cached, so that they see the writes
+// just made.
+func ReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func readsPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// replicas spreads the reads of a repository over read replicas of its
+// database. Replicas lag behind the primary, reads sent to them may miss the
+// latest writes.
+type replicas struct {
+	dbs  []*gorm.DB
+	next uint32
+}
+
+func newReplicas(dbs []*gorm.DB) *replicas {
+	return &replicas{dbs: dbs}
+}
+
+// read runs fn in a transaction of the next replica, in turn. It runs on
+// primary instead, see readStatement, when ctx asks for it, see ReadPrimary,
+// when there is no replica, or when the replica cannot start a transaction,
+// being down for instance.
+func (r *replicas) read(ctx context.Context, primary *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if len(r.dbs) == 0 || readsPrimary(ctx) {
+		return readStatement(ctx, primary, fn)
+	}
+
+	i := atomic.AddUint32(&r.next, 1) % uint32(len(r.dbs))
+	tx, err := begin(ctx, r.dbs[i])
+	if err == nil {
+		return end(ctx, tx, fn(tx))
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+	logging.FromContext(ctx).Warn("Read replica unavailable, reading from the primary", zap.Int("replica", int(i)), zap.Error(err))
+	return readStatement(ctx, primary, fn)
+}
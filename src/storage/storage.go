@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+)
+
+// Errors returned by the repositories, whatever the storage behind them.
+var (
+	// ErrNotFound reports a missing record.
+	ErrNotFound = errors.New("record not found")
+	// ErrAlreadyExists reports a record conflicting with a unique field of
+	// another one.
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrMissingReference reports a record referencing a missing one, such as
+	// an event whose author does not exist.
+	ErrMissingReference = errors.New("record references a missing record")
+)
+
+// UserRepository stores users.
+type UserRepository interface {
+	// Create stores u, hashing its password, and returns it with its ID set.
+	Create(ctx context.Context, u *models.User) (*models.User, error)
+	// Get returns the user with the given ID.
+	Get(ctx context.Context, id uint32) (*models.User, error)
+	// FindByEmail returns the user with the given email.
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	// List calls fn with every user, in ID order, until fn fails.
+	List(ctx context.Context, fn func(*models.User) error) error
+	// Update sets the nickname, email and password, hashed, of the user with
+	// the given ID to those of u, and returns the updated user.
+	Update(ctx context.Context, id uint32, u *models.User) (*models.User, error)
+	// Delete deletes the user with the given ID and returns the number of
+	// deleted users.
+	Delete(ctx context.Context, id uint32) (int64, error)
+}
+
+// EventRef identifies the event of an author.
+type EventRef struct {
+	ID       uint64
+	AuthorID uint32
+}
+
+// EventRepository stores events. Events are returned with their AuthorID,
+// their Author is not loaded.
+type EventRepository interface {
+	// Create stores e and returns it with its ID set. It fails with
+	// ErrMissingReference if its author does not exist.
+	Create(ctx context.Context, e *models.Event) (*models.Event, error)
+	// Get returns the event with the given ID.
+	Get(ctx context.Context, id uint64) (*models.Event, error)
+	// FindByTitle returns the event with the given title.
+	FindByTitle(ctx context.Context, title string) (*models.Event, error)
+	// List calls fn with every event, in ID order, until fn fails.
+	List(ctx context.Context, fn func(*models.Event) error) error
+	// ListByAuthor calls fn with every event of the author with the given ID,
+	// in ID order, until fn fails.
+	ListByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) error
+	// Update sets the title and content of the event with the given ID to
+	// those of e, and returns the updated event.
+	Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error)
+	// Delete deletes the event ref names and returns the number of deleted
+	// events.
+	Delete(ctx context.Context, ref EventRef) (int64, error)
+	// DeleteByAuthor calls fn with every event of the author with the given
+	// ID, in ID order, then deletes them unless fn failed. It returns the
+	// number of deleted events, events of the author created meanwhile being
+	// deleted too.
+	DeleteByAuthor(ctx context.Context, authorID uint32, fn func(*models.Event) error) (int64, error)
+}
+
+// UnitOfWork runs writes spanning several statements atomically.
+type UnitOfWork interface {
+	// Do calls fn with repositories whose writes are committed together when
+	// fn returns nil. They are rolled back when fn fails, or when ctx is done
+	// before they are committed, and Do returns that error.
+	Do(ctx context.Context, fn func(users UserRepository, events EventRepository) error) error
+}
@@ -0,0 +1,255 @@
+package tlsconfig
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/insecure"
+)
+
+// DefaultReloadInterval is how often certificate files are checked for changes.
+const DefaultReloadInterval = 10 * time.Second
+
+// Files names the PEM files making up a TLS configuration.
+type Files struct {
+	// CertFile and KeyFile hold the certificate and private key presented by
+	// the server, and by the gateway when it dials the server.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the certificate authorities trusted to sign peer
+	// certificates. The system roots are used to verify servers when empty.
+	CAFile string
+	// ClientAuth requires clients to present a certificate signed by CAFile.
+	ClientAuth bool
+	// ServerName overrides the name servers are verified against, for when
+	// the dialled address is not a name of their certificate.
+	ServerName string
+}
+
+// Reloader serves TLS configurations built from the current content of
+// certificate files, reloading them when they change on disk so that rotated
+// certificates are picked up without restarting.
+type Reloader struct {
+	files Files
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime map[string]time.Time
+
+	done chan struct{}
+	once sync.Once
+}
+
+// Insecure returns a Reloader serving the self signed certificate of the
+// insecure package. It never reloads.
+func Insecure() *Reloader {
+	return &Reloader{
+		cert: &insecure.Cert,
+		pool: insecure.CertPool,
+		done: make(chan struct{}),
+	}
+}
+
+// Load returns a Reloader for files checked every interval, or Insecure if
+// files names no certificate.
+func Load(files Files, interval time.Duration) (*Reloader, error) {
+	if files.CertFile == "" && files.KeyFile == "" {
+		return Insecure(), nil
+	}
+	return NewReloader(files, interval)
+}
+
+// NewReloader loads files and checks them for changes every interval until
+// Close is called.
+func NewReloader(files Files, interval time.Duration) (*Reloader, error) {
+	if files.CertFile == "" || files.KeyFile == "" {
+		return nil, errors.New("both a certificate and a key file are required")
+	}
+	if files.ClientAuth && files.CAFile == "" {
+		return nil, errors.New("a CA file is required to verify client certificates")
+	}
+
+	r := &Reloader{
+		files: files,
+		done:  make(chan struct{}),
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	go r.watch(interval)
+	return r, nil
+}
+
+// Close stops watching the files.
+func (r *Reloader) Close() {
+	r.once.Do(func() { close(r.done) })
+}
+
+// ServerConfig returns a configuration for servers, presenting the current
+// certificate and, with ClientAuth, requiring verified client certificates.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			if r.files.ClientAuth {
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+				cfg.ClientCAs = r.pool
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// ClientConfig returns a configuration for clients, verifying servers against
+// the current CA pool and presenting the current certificate when asked to.
+func (r *Reloader) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.files.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return r.cert, nil
+		},
+		// The standard verification is replaced by VerifyConnection so that
+		// it uses the CA pool loaded last rather than a fixed RootCAs.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("tls: server presented no certificate")
+			}
+
+			r.mu.RLock()
+			roots := r.pool
+			r.mu.RUnlock()
+
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				DNSName:       cs.ServerName,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+	}
+}
+
+// IsOwnCertificate reports whether cert is the certificate currently
+// presented by this configuration. A nil Reloader owns no certificate.
+func (r *Reloader) IsOwnCertificate(cert *x509.Certificate) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.cert.Certificate) > 0 && bytes.Equal(r.cert.Certificate[0], cert.Raw)
+}
+
+func (r *Reloader) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				// Keep serving the previous certificates, a rotation may be half written.
+				zap.L().Warn("Failed to reload TLS certificates, keeping the previous ones", zap.Error(err))
+				continue
+			}
+			zap.L().Info("Reloaded TLS certificates", zap.String("cert_file", r.files.CertFile))
+		}
+	}
+}
+
+func (r *Reloader) paths() []string {
+	paths := []string{r.files.CertFile, r.files.KeyFile}
+	if r.files.CAFile != "" {
+		paths = append(paths, r.files.CAFile)
+	}
+	return paths
+}
+
+// changed reports whether a file was modified since the last reload.
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(r.modTime[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) reload() error {
+	modTime := make(map[string]time.Time)
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		modTime[path] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return fmt.Errorf("loading key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.files.CAFile != "" {
+		pem, err := ioutil.ReadFile(r.files.CAFile)
+		if err != nil {
+			return fmt.Errorf("reading CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in CA file %s", r.files.CAFile)
+		}
+	} else {
+		pool, err = x509.SystemCertPool()
+		if err != nil {
+			return fmt.Errorf("loading system CA pool: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.pool = pool
+	r.modTime = modTime
+	return nil
+}
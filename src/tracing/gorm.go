@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	contextKey = "tracing:context"
+	spanKey    = "tracing:span"
+)
+
+// WithContext returns db carrying ctx, so that the queries made through it
+// are recorded as children of the span of ctx. gorm v1 has no context of its
+// own: the services pass theirs with this.
+func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Set(contextKey, ctx)
+}
+
+// InstrumentGorm records a span for every operation made through db with a
+// context set by WithContext. system is reported as the db.system of the
+// spans, such as the name of the driver.
+func InstrumentGorm(db *gorm.DB, system string) {
+	start := func(operation string) func(scope *gorm.Scope) {
+		return func(scope *gorm.Scope) {
+			v, ok := scope.Get(contextKey)
+			if !ok {
+				return
+			}
+			ctx, _ := v.(context.Context)
+			if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+				return
+			}
+			table := scope.TableName()
+			_, span := tracer().Start(ctx, operation+" "+table,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.DBSystemKey.String(system),
+					semconv.DBOperationKey.String(operation),
+					label.String("db.sql.table", table),
+				),
+			)
+			scope.InstanceSet(spanKey, span)
+		}
+	}
+	end := func(scope *gorm.Scope) {
+		v, ok := scope.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span := v.(trace.Span)
+		span.SetAttributes(
+			semconv.DBStatementKey.String(scope.SQL),
+			label.Int64("db.rows_affected", scope.DB().RowsAffected),
+		)
+		if err := scope.DB().Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:begin_transaction").Register("tracing:start", start("INSERT"))
+	callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("tracing:end", end)
+	callbacks.Update().Before("gorm:begin_transaction").Register("tracing:start", start("UPDATE"))
+	callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("tracing:end", end)
+	callbacks.Delete().Before("gorm:begin_transaction").Register("tracing:start", start("DELETE"))
+	callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("tracing:end", end)
+	callbacks.Query().Before("gorm:query").Register("tracing:start", start("SELECT"))
+	callbacks.Query().After("gorm:after_query").Register("tracing:end", end)
+	callbacks.RowQuery().Before("gorm:row_query").Register("tracing:start", start("SELECT"))
+	callbacks.RowQuery().After("gorm:row_query").Register("tracing:end", end)
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/propagation"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName names the tracer of the spans created by this module.
+const instrumentationName = "github.com/RemyRanger/taktyl_core_grpc"
+
+// Exporters
+const (
+	// ExporterNone only propagates the trace context, without recording spans.
+	ExporterNone = ""
+	// ExporterStdout prints the spans, for local debugging.
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends the spans to an OpenTelemetry collector.
+	ExporterOTLP = "otlp"
+)
+
+// Options configures Setup.
+type Options struct {
+	// ServiceName is reported as the service.name of every span.
+	ServiceName string
+	// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP.
+	Exporter string
+	// OTLPEndpoint is the host:port of the collector receiving OTLP over gRPC.
+	OTLPEndpoint string
+	// OTLPInsecure sends the spans to the collector without TLS.
+	OTLPInsecure bool
+	// SampleRatio is the fraction of the traces started here that are
+	// recorded. Traces started upstream follow the upstream decision.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and the W3C trace context and
+// baggage propagators. The returned function flushes the spans not exported
+// yet and must be called before exiting.
+func Setup(opts Options) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter exporttrace.SpanExporter
+	switch opts.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdout.NewExporter(
+			stdout.WithWriter(os.Stdout),
+			stdout.WithPrettyPrint(),
+			stdout.WithoutMetricExport(),
+		)
+	case ExporterOTLP:
+		otlpOpts := []otlp.ExporterOption{otlp.WithAddress(opts.OTLPEndpoint)}
+		if opts.OTLPInsecure {
+			otlpOpts = append(otlpOpts, otlp.WithInsecure())
+		}
+		exporter, err = otlp.NewExporter(otlpOpts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s exporter: %w", opts.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{
+			DefaultSampler: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio)),
+		}),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(opts.ServiceName))),
+		sdktrace.WithBatcher(exporter),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return err
+		}
+		return exporter.Shutdown(ctx)
+	}, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
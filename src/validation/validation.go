@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validator is implemented by messages generated by protoc-gen-validate.
+type validator interface {
+	Validate() error
+}
+
+// fieldError is implemented by the <Message>ValidationError types generated
+// by protoc-gen-validate.
+type fieldError interface {
+	error
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// UnaryServerInterceptor rejects requests that break the rules declared on
+// their proto message with InvalidArgument, before they reach the handler.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received on a stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+// validate returns an InvalidArgument status carrying an errdetails.BadRequest
+// when req fails validation.
+func validate(req interface{}) error {
+	v, ok := req.(validator)
+	if !ok {
+		return nil
+	}
+	err := v.Validate()
+	if err == nil {
+		return nil
+	}
+
+	violation := &errdetails.BadRequest_FieldViolation{Description: err.Error()}
+	var fe fieldError
+	if errors.As(err, &fe) {
+		violation.Field, violation.Description = fieldPath(fe)
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{violation},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// fieldPath follows the causes of nested message errors to build a dotted
+// field path and returns it with the innermost reason.
+func fieldPath(fe fieldError) (string, string) {
+	path, reason := fe.Field(), fe.Reason()
+	var nested fieldError
+	if cause := fe.Cause(); cause != nil && errors.As(cause, &nested) {
+		nestedPath, nestedReason := fieldPath(nested)
+		return path + "." + nestedPath, nestedReason
+	}
+	return path, reason
+}
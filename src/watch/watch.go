@@ -0,0 +1,185 @@
+package watch
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+)
+
+// watcherBuffer is the number of changes a watcher may fall behind before it
+// is dropped.
+const watcherBuffer = 64
+
+var (
+	// ErrCompacted is returned when resuming from a revision older than the
+	// changes kept by the hub.
+	ErrCompacted = errors.New("revision is no longer available")
+	// ErrFutureRevision is returned when resuming from a revision the hub has
+	// not reached, typically because the server restarted since.
+	ErrFutureRevision = errors.New("revision has not been reached")
+	// ErrClosed is returned when watching a closed hub.
+	ErrClosed = errors.New("hub is closed")
+	// ErrFellBehind is reported by watchers dropped for not keeping up.
+	ErrFellBehind = errors.New("watcher fell behind")
+)
+
+// Filter selects the changes sent to a watcher.
+type Filter func(*pbEvent.EventChange) bool
+
+// Hub fans event changes out to watchers. It keeps the last changes in a ring
+// so that reconnecting watchers can resume from the last revision they saw.
+// Revisions live in memory: they restart from zero with the process.
+type Hub struct {
+	mu       sync.Mutex
+	revision int64
+	history  []*pbEvent.EventChange
+	next     int
+	watchers map[*Watcher]struct{}
+	closed   bool
+}
+
+// NewHub returns a Hub keeping the last size changes.
+func NewHub(size int) *Hub {
+	return &Hub{
+		history:  make([]*pbEvent.EventChange, 0, size),
+		watchers: make(map[*Watcher]struct{}),
+	}
+}
+
+// Revision returns the revision of the last published change.
+func (h *Hub) Revision() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.revision
+}
+
+// Publish records a change of event and sends it to the matching watchers.
+// Watchers too slow to keep up are dropped.
+func (h *Hub) Publish(changeType pbEvent.EventChange_Type, event *pbEvent.EventDTO) *pbEvent.EventChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision++
+	change := &pbEvent.EventChange{
+		Revision:   h.revision,
+		ChangeType: changeType,
+		Event:      event,
+		ChangedAt:  ptypes.TimestampNow(),
+	}
+
+	if len(h.history) < cap(h.history) {
+		h.history = append(h.history, change)
+	} else if cap(h.history) > 0 {
+		h.history[h.next] = change
+		h.next = (h.next + 1) % cap(h.history)
+	}
+
+	for w := range h.watchers {
+		if w.filter != nil && !w.filter(change) {
+			continue
+		}
+		select {
+		case w.c <- change:
+		default:
+			h.drop(w, ErrFellBehind)
+		}
+	}
+	return change
+}
+
+// Watch registers a watcher receiving the changes after revision after that
+// match filter. A zero after only delivers changes published from now on.
+func (h *Hub) Watch(after int64, filter Filter) (*Watcher, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+	if after > h.revision {
+		return nil, ErrFutureRevision
+	}
+
+	var backlog []*pbEvent.EventChange
+	if after > 0 && after < h.revision {
+		changes := h.changes()
+		if len(changes) == 0 || changes[0].Revision > after+1 {
+			return nil, ErrCompacted
+		}
+		for _, change := range changes {
+			if change.Revision > after && (filter == nil || filter(change)) {
+				backlog = append(backlog, change)
+			}
+		}
+	}
+
+	w := &Watcher{
+		hub:    h,
+		filter: filter,
+		c:      make(chan *pbEvent.EventChange, len(backlog)+watcherBuffer),
+	}
+	w.C = w.c
+	for _, change := range backlog {
+		w.c <- change
+	}
+	h.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// changes returns the kept changes, oldest first.
+func (h *Hub) changes() []*pbEvent.EventChange {
+	return append(append([]*pbEvent.EventChange(nil), h.history[h.next:]...), h.history[:h.next]...)
+}
+
+// Close closes every watcher and refuses new ones, so that streams end on
+// shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for w := range h.watchers {
+		h.drop(w, ErrClosed)
+	}
+}
+
+func (h *Hub) drop(w *Watcher, err error) {
+	if _, ok := h.watchers[w]; ok {
+		delete(h.watchers, w)
+		w.err = err
+		close(w.c)
+	}
+}
+
+// Watcher receives changes from a Hub.
+type Watcher struct {
+	// C delivers the changes in revision order. It is closed when the watcher
+	// or the hub is closed, or when the watcher falls too far behind.
+	C <-chan *pbEvent.EventChange
+
+	hub    *Hub
+	filter Filter
+	c      chan *pbEvent.EventChange
+	err    error
+}
+
+// Err reports why C was closed: ErrFellBehind, ErrClosed if the hub was
+// closed, or nil if the watcher was.
+func (w *Watcher) Err() error {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+
+	return w.err
+}
+
+// Close unregisters the watcher.
+func (w *Watcher) Close() {
+	w.hub.mu.Lock()
+	defer w.hub.mu.Unlock()
+
+	w.hub.drop(w, nil)
+}
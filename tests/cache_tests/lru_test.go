@@ -0,0 +1,57 @@
+package cachetests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/cache"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewLRU(2)
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "b", []byte("2"), time.Minute)
+	// a is now more recently used than b
+	_, ok, _ := c.Get(ctx, "a")
+	assert.Equal(t, ok, true)
+	c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	_, ok, _ = c.Get(ctx, "b")
+	assert.Equal(t, ok, false)
+	value, ok, _ := c.Get(ctx, "a")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, string(value), "1")
+	assert.Equal(t, c.Len(), 2)
+
+	c.Set(ctx, "a", []byte("4"), time.Minute)
+	value, _, _ = c.Get(ctx, "a")
+	assert.Equal(t, string(value), "4")
+	assert.Equal(t, c.Len(), 2)
+}
+
+func TestLRUExpiresAndDeletes(t *testing.T) {
+	c := cache.NewLRU(10)
+	ctx := context.Background()
+
+	c.Set(ctx, "short", []byte("1"), time.Millisecond)
+	c.Set(ctx, "long", []byte("2"), time.Minute)
+	c.Set(ctx, "deleted", []byte("3"), time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, _ := c.Get(ctx, "short")
+	assert.Equal(t, ok, false)
+	_, ok, _ = c.Get(ctx, "long")
+	assert.Equal(t, ok, true)
+
+	if err := c.Delete(ctx, "deleted", "missing"); err != nil {
+		t.Fatalf("this is the error deleting the keys: %v\n", err)
+	}
+	_, ok, _ = c.Get(ctx, "deleted")
+	assert.Equal(t, ok, false)
+	assert.Equal(t, c.Len(), 1)
+}
@@ -0,0 +1,211 @@
+package configtests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/config"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("this is the error writing the config: %v\n", err)
+	}
+	return path
+}
+
+func TestLayersPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+server:
+  port: 12000
+db:
+  name: from_file
+  user: from_file
+api:
+  idempotency_ttl: 1h
+`)
+	os.Setenv("DB_USER", "from_env")
+	os.Setenv("DB_NAME", "from_env")
+	defer os.Unsetenv("DB_USER")
+	defer os.Unsetenv("DB_NAME")
+
+	cfg, printConfig, err := config.Load("test", []string{"--config", path, "--db-name", "from_flag"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, printConfig, false)
+	assert.Equal(t, cfg.Server.Port, 12000)
+	assert.Equal(t, cfg.Server.Addr(), "0.0.0.0:12000")
+	assert.Equal(t, cfg.DB.User, "from_env")
+	assert.Equal(t, cfg.DB.Name, "from_flag")
+	assert.Equal(t, time.Duration(cfg.API.IdempotencyTTL), time.Hour)
+	assert.Equal(t, cfg.API.MaxBatchSize, config.Default().API.MaxBatchSize)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	_, _, err := config.Load("test", []string{"--config", writeConfig(t, "server:\n  prot: 12000\n")})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--max-batch-size", "0"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--tls-cert-file", "cert.pem"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--log-level", "verbose"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--db-driver", "oracle"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--rate-limit-trusted-proxies", "10.0.0.1"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestPrintConfigRedactsSecrets(t *testing.T) {
+	cfg, printConfig, err := config.Load("test", []string{"--print-config", "--db-password", "s3cr3t", "--rate-limit-api-keys", "k3y", "--tls-cert-file", "cert.pem", "--tls-key-file", "secret-key.pem"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, printConfig, true)
+
+	var out bytes.Buffer
+	if err := cfg.PrintYAML(&out); err != nil {
+		t.Fatalf("this is the error printing the config: %v\n", err)
+	}
+	assert.Equal(t, strings.Contains(out.String(), "s3cr3t"), false)
+	assert.Equal(t, strings.Contains(out.String(), "k3y"), false)
+	assert.Equal(t, strings.Contains(out.String(), "secret-key.pem"), false)
+	assert.Equal(t, strings.Contains(out.String(), "password: REDACTED"), true)
+	assert.Equal(t, cfg.DB.Password, "s3cr3t")
+	assert.Equal(t, cfg.RateLimit.APIKeys, []string{"k3y"})
+}
+
+func TestRateLimitMethods(t *testing.T) {
+	cfg, _, err := config.Load("test", []string{
+		"--rate-limit-rate", "10", "--rate-limit-burst", "20",
+		"--rate-limit-methods", "/event.EventService/AddEvent=0.5:5, /user.UserService/ListUsers=2:10",
+	})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	limits := cfg.RateLimit.Limits()
+	assert.Equal(t, limits.For("/event.EventService/AddEvent"), ratelimit.Limit{Rate: 0.5, Burst: 5})
+	assert.Equal(t, limits.For("/user.UserService/ListUsers"), ratelimit.Limit{Rate: 2, Burst: 10})
+	assert.Equal(t, limits.For("/user.UserService/GetUser"), ratelimit.Limit{Rate: 10, Burst: 20})
+
+	_, _, err = config.Load("test", []string{"--rate-limit-methods", "AddEvent=1:5"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--rate-limit-rate", "1"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestSeedSets(t *testing.T) {
+	cfg, _, err := config.Load("test", []string{"--seed-sets", "demo, test"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.Seed.Dir, "fixtures")
+	assert.Equal(t, cfg.Seed.Sets, []string{"demo", "test"})
+
+	_, _, err = config.Load("test", []string{"--seed-dir", "", "--seed-sets", "demo"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestDBPool(t *testing.T) {
+	cfg, _, err := config.Load("test", []string{"--db-max-open-conns", "8", "--db-max-idle-conns", "4", "--db-conn-max-lifetime", "1m"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.DB.Pool(), storage.Pool{MaxOpenConns: 8, MaxIdleConns: 4, ConnMaxLifetime: time.Minute})
+	assert.Equal(t, cfg.DB.ConnectTimeout, config.Duration(30*time.Second))
+
+	_, _, err = config.Load("test", []string{"--db-max-open-conns", "2", "--db-max-idle-conns", "4"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--db-connect-timeout", "-1s"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestDBReplicas(t *testing.T) {
+	cfg, _, err := config.Load("test", []string{"--db-driver", "postgres", "--db-replicas", "replica1:5432,replica2:5432", "--db-read-your-writes", "10s"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.DB.Replicas, []string{"replica1:5432", "replica2:5432"})
+	assert.Equal(t, cfg.DB.ReadYourWrites, config.Duration(10*time.Second))
+
+	_, _, err = config.Load("test", []string{"--db-driver", "sqlite3", "--db-name", "taktyl.db", "--db-replicas", "replica1:5432"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--db-driver", "postgres", "--db-replicas", "replica1"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--db-read-your-writes", "-1s"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestCache(t *testing.T) {
+	cfg, _, err := config.Load("test", []string{"--cache-size", "500", "--cache-ttl", "1m"})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.Cache, config.Cache{Size: 500, TTL: config.Duration(time.Minute)})
+
+	_, _, err = config.Load("test", []string{"--cache-size", "0", "--cache-ttl", "0s"})
+	assert.Equal(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--cache-size", "-1"})
+	assert.NotEqual(t, err, nil)
+
+	_, _, err = config.Load("test", []string{"--cache-ttl", "0s"})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestEmptyVariables(t *testing.T) {
+	os.Setenv("METRICS_ADDR", "")
+	os.Setenv("PORT", "")
+	defer os.Unsetenv("METRICS_ADDR")
+	defer os.Unsetenv("PORT")
+
+	cfg, _, err := config.Load("test", nil)
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.Server.MetricsAddr, "")
+	assert.Equal(t, cfg.Server.Port, config.Default().Server.Port)
+}
+
+func TestGatewayDefaults(t *testing.T) {
+	cfg := config.Default()
+	// The standalone gateway proxies to the server, listening apart from it
+	assert.Equal(t, cfg.Gateway.ServerAddress, "dns:///"+cfg.Server.Addr())
+	assert.NotEqual(t, cfg.Gateway.Addr(cfg.Server), cfg.Server.Addr())
+	assert.NotEqual(t, cfg.Gateway.MetricsAddr, cfg.Server.MetricsAddr)
+
+	_, _, err := config.Load("test", []string{"--gateway-port", "0"})
+	assert.NotEqual(t, err, nil)
+
+	cfg, _, err = config.Load("test", []string{"--gateway-metrics-addr", ""})
+	if err != nil {
+		t.Fatalf("this is the error loading the config: %v\n", err)
+	}
+	assert.Equal(t, cfg.Gateway.MetricsAddr, "")
+}
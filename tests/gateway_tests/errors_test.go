@@ -0,0 +1,116 @@
+package gatewaytests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// brokenEvents fails to read events as a lost database would.
+type brokenEvents struct {
+	storage.EventRepository
+}
+
+func (brokenEvents) Get(ctx context.Context, id uint64) (*models.Event, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+// problem is the application/problem+json body of error responses.
+type problem struct {
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Status     int    `json:"status"`
+	Detail     string `json:"detail"`
+	Code       string `json:"code"`
+	Violations []struct {
+		Field       string `json:"field"`
+		Description string `json:"description"`
+	} `json:"violations"`
+}
+
+func TestProblemResponses(t *testing.T) {
+	mem := storage.NewMemory()
+	backend := server.New(storage.NewMemoryUserRepository(mem), brokenEvents{storage.NewMemoryEventRepository(mem)}, storage.NewMemoryUnitOfWork(mem))
+	srv := startGateway(t, backend)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		// want is the expected body, but for the violation descriptions
+		want problem
+		// wantFields are the fields of the expected violations
+		wantFields []string
+	}{
+		{
+			name:   "not found",
+			method: http.MethodGet,
+			path:   "/api/v1/users/42",
+			want:   problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "user not found", Code: "NotFound"},
+		},
+		{
+			name:       "invalid argument",
+			method:     http.MethodPost,
+			path:       "/api/v1/users",
+			body:       `{"Nickname":"pet","Email":"not an email","Password":"password"}`,
+			want:       problem{Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest, Code: "InvalidArgument"},
+			wantFields: []string{"Email"},
+		},
+		{
+			name:   "internal",
+			method: http.MethodGet,
+			path:   "/api/v1/events/1",
+			want:   problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError, Detail: "Error while accessing event in database", Code: "Internal"},
+		},
+		{
+			name:   "unknown route",
+			method: http.MethodGet,
+			path:   "/api/v1/unknown",
+			want:   problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "Not Found", Code: "NotFound"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("this is the error building the request: %v\n", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("this is the error sending the request: %v\n", err)
+			}
+			defer resp.Body.Close()
+
+			assert.Equal(t, resp.StatusCode, tt.want.Status)
+			assert.Equal(t, resp.Header.Get("Content-Type"), "application/problem+json")
+			var got problem
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("this is the error decoding the problem: %v\n", err)
+			}
+
+			var fields []string
+			for _, v := range got.Violations {
+				fields = append(fields, v.Field)
+				assert.NotEqual(t, v.Description, "")
+			}
+			assert.Equal(t, fields, tt.wantFields)
+			got.Violations = nil
+			if tt.wantFields != nil {
+				// The detail sums the violations up
+				assert.NotEqual(t, got.Detail, "")
+				got.Detail = ""
+			}
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gatewaytests
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/validation"
+)
+
+// startGateway serves backend over gRPC, validating the requests, and the
+// gateway proxying to it over cleartext HTTP.
+func startGateway(t *testing.T, backend *server.Backend) *httptest.Server {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(validation.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(validation.StreamServerInterceptor()),
+	)
+	pbUser.RegisterUserServiceServer(s, backend)
+	pbEvent.RegisterEventServiceServer(s, backend)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	conn, err := gateway.Dial(ctx, lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("this is the error dialing the server: %v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	handler, err := gateway.New(ctx, conn)
+	if err != nil {
+		t.Fatalf("this is the error creating the gateway: %v\n", err)
+	}
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
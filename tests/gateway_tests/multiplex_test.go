@@ -0,0 +1,100 @@
+package gatewaytests
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+)
+
+// startMultiplex serves a gRPC server with the health service and a REST
+// handler answering "rest" on one TLS port, HTTP/2 enabled.
+func startMultiplex(t *testing.T) *httptest.Server {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("rest"))
+	})
+
+	srv := httptest.NewUnstartedServer(gateway.Multiplex(grpcServer, rest))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMultiplexNativeGRPC(t *testing.T) {
+	srv := startMultiplex(t)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	conn, err := grpc.Dial(srv.Listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(roots, "")))
+	if err != nil {
+		t.Fatalf("this is the error dialing the server: %v\n", err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("this is the error checking the health: %v\n", err)
+	}
+	assert.Equal(t, resp.Status, healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestMultiplexHTTP(t *testing.T) {
+	srv := startMultiplex(t)
+
+	// An empty HealthCheckRequest, framed
+	emptyMessage := []byte{0, 0, 0, 0, 0}
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        []byte
+		// wantType and wantBody are the Content-Type and part of the body
+		// of the response
+		wantType string
+		wantBody string
+	}{
+		{"gRPC-Web", "/grpc.health.v1.Health/Check", "application/grpc-web+proto", emptyMessage, "application/grpc-web+proto", "grpc-status: 0"},
+		{"gRPC-Web text", "/grpc.health.v1.Health/Check", "application/grpc-web-text", []byte("AAAAAAA="), "application/grpc-web-text", ""},
+		{"REST", "/api/v1/users/1", "application/json", nil, "text/plain; charset=utf-8", "rest"},
+		{"REST without Content-Type", "/api/v1/users/1", "", nil, "text/plain; charset=utf-8", "rest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, srv.URL+tt.path, bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("this is the error building the request: %v\n", err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("this is the error sending the request: %v\n", err)
+			}
+			defer resp.Body.Close()
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("this is the error reading the response: %v\n", err)
+			}
+
+			assert.Equal(t, resp.StatusCode, http.StatusOK)
+			assert.Equal(t, resp.Header.Get("Content-Type"), tt.wantType)
+			assert.Equal(t, strings.Contains(string(body), tt.wantBody), true)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package gatewaytests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/go-playground/assert.v1"
+
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// newBackend returns a Backend on memory storage, with one user.
+func newBackend(t *testing.T) (*server.Backend, int32) {
+	mem := storage.NewMemory()
+	backend := server.New(storage.NewMemoryUserRepository(mem), storage.NewMemoryEventRepository(mem), storage.NewMemoryUnitOfWork(mem))
+	user, err := backend.AddUser(context.Background(), &pbUser.AddUserRequest{Nickname: "pet", Email: "pet@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error adding the user: %v\n", err)
+	}
+	return backend, user.ID
+}
+
+// sseEvent is a Server-Sent Event, its data lines joined.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event of r.
+func readEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	var e sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("this is the error reading the stream: %v\n", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return e
+		case strings.HasPrefix(line, "event: "):
+			e.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data += strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// watch starts watching events over Server-Sent Events, selected by accept
+// or, when empty, by the stream query parameter.
+func watch(t *testing.T, ctx context.Context, url, accept string) (*http.Response, *bufio.Reader) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("this is the error building the request: %v\n", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("this is the error sending the request: %v\n", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp, bufio.NewReader(resp.Body)
+}
+
+func TestWatchServerSentEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		accept string
+	}{
+		{"Accept header", "", "text/event-stream"},
+		{"stream parameter", "&stream=sse", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, authorID := newBackend(t)
+			srv := startGateway(t, backend)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			event, err := backend.AddEvent(ctx, &pbEvent.AddEventRequest{Title: "Watched", Content: "content", AuthorID: authorID})
+			if err != nil {
+				t.Fatalf("this is the error adding the event: %v\n", err)
+			}
+			// Only the changes made once watching are streamed, the headers
+			// coming with the first one
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				backend.DeleteEvent(context.Background(), &pbEvent.DeleteEventRequest{EventId: event.ID, AuthorId: int64(authorID)})
+			}()
+			resp, r := watch(t, ctx, srv.URL+"/api/v1/events:watch?StartRevision=0"+tt.query, tt.accept)
+			assert.Equal(t, resp.StatusCode, http.StatusOK)
+			assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+			var result struct {
+				Result struct {
+					Revision   string
+					ChangeType string
+					Event      struct{ ID string }
+				} `json:"result"`
+			}
+			e := readEvent(t, r)
+			assert.Equal(t, e.name, "")
+			if err := json.Unmarshal([]byte(e.data), &result); err != nil {
+				t.Fatalf("this is the error decoding %q: %v\n", e.data, err)
+			}
+			assert.Equal(t, result.Result.ChangeType, "DELETED")
+			assert.Equal(t, result.Result.Revision, "2")
+		})
+	}
+}
+
+func TestWatchServerSentEventsError(t *testing.T) {
+	backend, _ := newBackend(t)
+	srv := startGateway(t, backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// No change was made yet
+	_, r := watch(t, ctx, srv.URL+"/api/v1/events:watch?StartRevision=42", "text/event-stream")
+	e := readEvent(t, r)
+	assert.Equal(t, e.name, "error")
+	var chunk struct {
+		Error struct {
+			Code    int
+			Message string
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(e.data), &chunk); err != nil {
+		t.Fatalf("this is the error decoding %q: %v\n", e.data, err)
+	}
+	// OutOfRange
+	assert.Equal(t, chunk.Error.Code, 11)
+}
+
+func TestWatchWebSocket(t *testing.T) {
+	backend, authorID := newBackend(t)
+	srv := startGateway(t, backend)
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/api/v1/events:watch", nil)
+	if err != nil {
+		t.Fatalf("this is the error dialing the websocket: %v\n", err)
+	}
+	defer conn.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusSwitchingProtocols)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		backend.AddEvent(context.Background(), &pbEvent.AddEventRequest{Title: "Watched", Content: "content", AuthorID: authorID})
+	}()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	kind, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("this is the error reading the websocket: %v\n", err)
+	}
+	assert.Equal(t, kind, websocket.TextMessage)
+	var result struct {
+		Result struct{ ChangeType string } `json:"result"`
+	}
+	if err := json.Unmarshal(msg, &result); err != nil {
+		t.Fatalf("this is the error decoding %q: %v\n", msg, err)
+	}
+	assert.Equal(t, result.Result.ChangeType, "CREATED")
+
+	// Closing the socket cancels the watch
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
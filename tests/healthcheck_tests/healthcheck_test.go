@@ -0,0 +1,63 @@
+package healthchecktests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/healthcheck"
+)
+
+// fakeDB fails pings while down is set.
+type fakeDB struct {
+	down int32
+}
+
+func (db *fakeDB) PingContext(ctx context.Context) error {
+	if atomic.LoadInt32(&db.down) == 1 {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func servingStatus(t *testing.T, srv *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("this is the error checking %q: %v\n", service, err)
+	}
+	return resp.Status
+}
+
+func TestMonitorFollowsDatabase(t *testing.T) {
+	srv := health.NewServer()
+	srv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	db := &fakeDB{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go healthcheck.Monitor(ctx, srv, db, 10*time.Millisecond, "user.UserService")
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, servingStatus(t, srv, ""), healthpb.HealthCheckResponse_SERVING)
+	assert.Equal(t, servingStatus(t, srv, "user.UserService"), healthpb.HealthCheckResponse_SERVING)
+
+	atomic.StoreInt32(&db.down, 1)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, servingStatus(t, srv, ""), healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, servingStatus(t, srv, "user.UserService"), healthpb.HealthCheckResponse_NOT_SERVING)
+
+	atomic.StoreInt32(&db.down, 0)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, servingStatus(t, srv, ""), healthpb.HealthCheckResponse_SERVING)
+
+	// Shutdown wins over later pings
+	srv.Shutdown()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, servingStatus(t, srv, ""), healthpb.HealthCheckResponse_NOT_SERVING)
+}
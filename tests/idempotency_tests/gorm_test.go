@@ -0,0 +1,78 @@
+package idempotencytests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/idempotency"
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestGormStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "idempotency")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", filepath.Join(dir, "taktyl.db"), storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+
+	store := idempotency.NewGormStore(db)
+	ctx := context.Background()
+	reserve := func(key string, expiresAt time.Time) *models.IdempotencyKey {
+		existing, err := store.Reserve(ctx, &models.IdempotencyKey{Key: key, Token: "token", Method: addUserMethod, Fingerprint: "f", ExpiresAt: expiresAt})
+		if err != nil {
+			t.Fatalf("this is the error reserving the key: %v\n", err)
+		}
+		return existing
+	}
+
+	assert.Equal(t, reserve("held", time.Now().Add(time.Hour)), (*models.IdempotencyKey)(nil))
+	assert.NotEqual(t, reserve("held", time.Now().Add(time.Hour)), (*models.IdempotencyKey)(nil))
+	// Only the reservation holding the key completes or releases it
+	if err := store.Complete(ctx, "held", "other-token", "user.UserDTO", []byte{1}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("this is the error completing the key: %v\n", err)
+	}
+	if err := store.Release(ctx, "held", "other-token"); err != nil {
+		t.Fatalf("this is the error releasing the key: %v\n", err)
+	}
+	assert.Equal(t, reserve("held", time.Now().Add(time.Hour)).Completed, false)
+	if err := store.Complete(ctx, "held", "token", "user.UserDTO", []byte{1}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("this is the error completing the key: %v\n", err)
+	}
+	assert.Equal(t, reserve("held", time.Now().Add(time.Hour)).Completed, true)
+
+	// Expired records are replaced, and deleted by Sweep
+	reserve("expired", time.Now().Add(-time.Second))
+	assert.Equal(t, reserve("expired", time.Now().Add(-time.Second)), (*models.IdempotencyKey)(nil))
+	reserve("other", time.Now().Add(-time.Second))
+	n, err := store.Sweep(ctx)
+	if err != nil {
+		t.Fatalf("this is the error sweeping: %v\n", err)
+	}
+	assert.Equal(t, n, int64(2))
+
+	// Queries are bound to the context of the request
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = store.Reserve(canceled, &models.IdempotencyKey{Key: "canceled", Method: addUserMethod, Fingerprint: "f", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,255 @@
+package idempotencytests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/idempotency"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+)
+
+const addUserMethod = "/user.UserService/AddUser"
+
+var addUserInfo = &grpc.UnaryServerInfo{FullMethod: addUserMethod}
+
+const clientKey = "x-client"
+
+func withKey(key string) context.Context {
+	return withClientKey("pet", key)
+}
+
+func withClientKey(client, key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(clientKey, client, idempotency.MetadataKey, key))
+}
+
+// client identifies clients by the metadata they send.
+func client(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return md.Get(clientKey)[0]
+}
+
+func TestReplayOnRetry(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour, time.Minute, client, addUserMethod)
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &pbUser.UserDTO{ID: int32(calls), Nickname: req.(*pbUser.AddUserRequest).Nickname}, nil
+	}
+	req := &pbUser.AddUserRequest{Nickname: "pet", Email: "pet@gmail.com", Password: "password"}
+
+	first, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the first call: %v\n", err)
+	}
+	second, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the retry: %v\n", err)
+	}
+
+	assert.Equal(t, calls, 1)
+	assert.Equal(t, second.(*pbUser.UserDTO).ID, first.(*pbUser.UserDTO).ID)
+	assert.Equal(t, second.(*pbUser.UserDTO).Nickname, "pet")
+}
+
+func TestRejectKeyReuseWithDifferentPayload(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour, time.Minute, client, addUserMethod)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pbUser.UserDTO{ID: 1}, nil
+	}
+
+	_, err := interceptor(withKey("key-1"), &pbUser.AddUserRequest{Nickname: "pet"}, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the first call: %v\n", err)
+	}
+	_, err = interceptor(withKey("key-1"), &pbUser.AddUserRequest{Nickname: "sam"}, addUserInfo, handler)
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+}
+
+func TestFailedRequestCanBeRetried(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour, time.Minute, client, addUserMethod)
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("database unavailable")
+		}
+		return &pbUser.UserDTO{ID: 1}, nil
+	}
+	req := &pbUser.AddUserRequest{Nickname: "pet"}
+
+	_, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+	assert.NotEqual(t, err, nil)
+
+	resp, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the retry: %v\n", err)
+	}
+	assert.Equal(t, calls, 2)
+	assert.Equal(t, resp.(*pbUser.UserDTO).ID, int32(1))
+}
+
+func TestExpiredKeyIsReusable(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), -time.Second, time.Minute, client, addUserMethod)
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &pbUser.UserDTO{ID: int32(calls)}, nil
+	}
+
+	_, err := interceptor(withKey("key-1"), &pbUser.AddUserRequest{Nickname: "pet"}, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the first call: %v\n", err)
+	}
+	_, err = interceptor(withKey("key-1"), &pbUser.AddUserRequest{Nickname: "sam"}, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error after expiry: %v\n", err)
+	}
+	assert.Equal(t, calls, 2)
+}
+
+// failingStore fails to store responses.
+type failingStore struct {
+	*idempotency.MemoryStore
+}
+
+func (s failingStore) Complete(ctx context.Context, key, token, responseType string, response []byte, expiresAt time.Time) error {
+	return errors.New("database unavailable")
+}
+
+func TestLeaseFreesKeyWhenResponseIsNotStored(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(failingStore{idempotency.NewMemoryStore()}, time.Hour, 50*time.Millisecond, client, addUserMethod)
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &pbUser.UserDTO{ID: int32(calls)}, nil
+	}
+	req := &pbUser.AddUserRequest{Nickname: "pet"}
+
+	// The request succeeded, so does the RPC
+	resp, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the first call: %v\n", err)
+	}
+	assert.Equal(t, resp.(*pbUser.UserDTO).ID, int32(1))
+
+	// Its key is held until the lease expires
+	_, err = interceptor(withKey("key-1"), req, addUserInfo, handler)
+	assert.Equal(t, status.Code(err), codes.Aborted)
+
+	time.Sleep(60 * time.Millisecond)
+	resp, err = interceptor(withKey("key-1"), req, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error once the lease expired: %v\n", err)
+	}
+	assert.Equal(t, resp.(*pbUser.UserDTO).ID, int32(2))
+}
+
+func TestLeaseExpiringMidRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		// fail makes the request outliving its lease fail
+		fail bool
+	}{
+		{"completed", false},
+		{"failed", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour, 50*time.Millisecond, client, addUserMethod)
+			req := &pbUser.AddUserRequest{Nickname: "pet"}
+
+			calls := 0
+			retried := make(chan struct{})
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				calls++
+				return &pbUser.UserDTO{ID: int32(calls)}, nil
+			}
+			slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+				<-retried
+				if tt.fail {
+					return nil, errors.New("database unavailable")
+				}
+				return &pbUser.UserDTO{ID: 42}, nil
+			}
+
+			done := make(chan error)
+			go func() {
+				_, err := interceptor(withKey("key-1"), req, addUserInfo, slow)
+				done <- err
+			}()
+
+			// A retry takes the key over once the lease expired
+			time.Sleep(60 * time.Millisecond)
+			resp, err := interceptor(withKey("key-1"), req, addUserInfo, handler)
+			if err != nil {
+				t.Fatalf("this is the error on the retry: %v\n", err)
+			}
+			assert.Equal(t, resp.(*pbUser.UserDTO).ID, int32(1))
+			close(retried)
+			err = <-done
+			assert.Equal(t, err != nil, tt.fail)
+
+			// The response of the retry is the one replayed
+			resp, err = interceptor(withKey("key-1"), req, addUserInfo, handler)
+			if err != nil {
+				t.Fatalf("this is the error on the replay: %v\n", err)
+			}
+			assert.Equal(t, calls, 1)
+			assert.Equal(t, resp.(*pbUser.UserDTO).ID, int32(1))
+		})
+	}
+}
+
+func TestKeysAreScopedPerClient(t *testing.T) {
+	interceptor := idempotency.UnaryServerInterceptor(idempotency.NewMemoryStore(), time.Hour, time.Minute, client, addUserMethod)
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &pbUser.UserDTO{ID: int32(calls)}, nil
+	}
+
+	first, err := interceptor(withClientKey("pet", "key-1"), &pbUser.AddUserRequest{Nickname: "pet"}, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the first call: %v\n", err)
+	}
+	// Another client using the same key with another payload is not rejected
+	second, err := interceptor(withClientKey("sam", "key-1"), &pbUser.AddUserRequest{Nickname: "sam"}, addUserInfo, handler)
+	if err != nil {
+		t.Fatalf("this is the error on the call of another client: %v\n", err)
+	}
+	assert.Equal(t, calls, 2)
+	assert.NotEqual(t, second.(*pbUser.UserDTO).ID, first.(*pbUser.UserDTO).ID)
+}
+
+func TestSweep(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	interceptor := idempotency.UnaryServerInterceptor(store, -time.Second, time.Minute, client, addUserMethod)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pbUser.UserDTO{ID: 1}, nil
+	}
+	for _, key := range []string{"key-1", "key-2"} {
+		if _, err := interceptor(withKey(key), &pbUser.AddUserRequest{Nickname: "pet"}, addUserInfo, handler); err != nil {
+			t.Fatalf("this is the error on the call: %v\n", err)
+		}
+	}
+
+	n, err := store.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("this is the error sweeping: %v\n", err)
+	}
+	assert.Equal(t, n, int64(2))
+}
@@ -0,0 +1,114 @@
+package loggingtests
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+	"github.com/RemyRanger/taktyl_core_grpc/src/logging"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+)
+
+// serve serves the health service and an unimplemented user service,
+// logging to the returned observer.
+func serve(t *testing.T) (*observer.ObservedLogs, *grpc.ClientConn) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core)
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger)),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger)),
+	)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	pbUser.RegisterUserServiceServer(s, &pbUser.UnimplementedUserServiceServer{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := gateway.Dial(context.Background(), lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("this is the error dialing: %v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return logs, conn
+}
+
+func TestRequestIDFromMetadata(t *testing.T) {
+	logs, conn := serve(t)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), logging.RequestIDKey, "req-1")
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("this is the error checking health: %v\n", err)
+	}
+
+	assert.Equal(t, header.Get(logging.RequestIDKey), []string{"req-1"})
+
+	entries := logs.FilterMessage("Finished call").All()
+	assert.Equal(t, len(entries), 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, fields["request_id"], "req-1")
+	assert.Equal(t, fields["grpc.method"], "/grpc.health.v1.Health/Check")
+	assert.Equal(t, fields["grpc.code"], "OK")
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	logs, conn := serve(t)
+
+	var header metadata.MD
+	// Control characters would garble the logs, the ID is replaced
+	ctx := metadata.AppendToOutgoingContext(context.Background(), logging.RequestIDKey, "bad id")
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("this is the error checking health: %v\n", err)
+	}
+
+	ids := header.Get(logging.RequestIDKey)
+	assert.Equal(t, len(ids), 1)
+	assert.NotEqual(t, ids[0], "bad id")
+	assert.Equal(t, logs.FilterField(zap.String("request_id", ids[0])).Len(), 1)
+}
+
+func TestGatewayForwardsRequestID(t *testing.T) {
+	logs, conn := serve(t)
+
+	gw, err := gateway.New(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("this is the error creating the gateway: %v\n", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	req.Header.Set(logging.RequestIDHeader, "req-2")
+	w := httptest.NewRecorder()
+	gw.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusNotImplemented)
+	assert.Equal(t, w.Header().Get(logging.RequestIDHeader), "req-2")
+	assert.Equal(t, w.Header().Get("Grpc-Metadata-X-Request-Id"), "")
+
+	entries := logs.FilterField(zap.String("request_id", "req-2")).All()
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].ContextMap()["grpc.code"], "Unimplemented")
+
+	// Requests without an ID get one
+	w = httptest.NewRecorder()
+	gw.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil))
+	id := w.Header().Get(logging.RequestIDHeader)
+	assert.NotEqual(t, id, "")
+	assert.Equal(t, logs.FilterField(zap.String("request_id", id)).Len(), 1)
+}
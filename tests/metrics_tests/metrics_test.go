@@ -0,0 +1,74 @@
+package metricstests
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/metrics"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestInstrumentHandler(t *testing.T) {
+	h := metrics.InstrumentHandler("test", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/found" {
+			http.NotFound(w, r)
+		}
+	}))
+	for _, path := range []string{"/found", "/found", "/missing"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	srv := metrics.NewServer()
+	go srv.Serve(lis)
+	defer srv.Shutdown(context.Background())
+
+	resp, err := http.Get("http://" + lis.Addr().String() + "/metrics")
+	if err != nil {
+		t.Fatalf("this is the error scraping: %v\n", err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, strings.Contains(string(body), `taktyl_http_requests_total{code="200",handler="test",method="get"} 2`), true)
+	assert.Equal(t, strings.Contains(string(body), `taktyl_http_requests_total{code="404",handler="test",method="get"} 1`), true)
+	assert.Equal(t, strings.Contains(string(body), `taktyl_http_requests_in_flight{handler="test"} 0`), true)
+}
+
+func TestInstrumentGormPools(t *testing.T) {
+	// A primary and a replica export their pools and queries apart
+	for _, name := range []string{"test-primary", "test-replica"} {
+		db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", storage.SQLiteMemory, storage.Pool{})
+		if err != nil {
+			t.Fatalf("this is the error opening the database: %v\n", err)
+		}
+		defer db.Close()
+		metrics.InstrumentGorm(db, name)
+		if err := db.Exec("CREATE TABLE users (id integer)").Error; err != nil {
+			t.Fatalf("this is the error creating the table: %v\n", err)
+		}
+		if err := db.Table("users").Find(&[]struct{ ID int }{}).Error; err != nil {
+			t.Fatalf("this is the error querying the table: %v\n", err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	assert.Equal(t, strings.Contains(body, `taktyl_db_pool_max_open_connections{db="test-primary"} 1`), true)
+	assert.Equal(t, strings.Contains(body, `taktyl_db_pool_max_open_connections{db="test-replica"} 1`), true)
+	assert.Equal(t, strings.Contains(body, `taktyl_db_query_duration_seconds_count{db="test-primary",operation="query",table="users"} 1`), true)
+	assert.Equal(t, strings.Contains(body, `taktyl_db_query_duration_seconds_count{db="test-replica",operation="query",table="users"} 1`), true)
+}
@@ -0,0 +1,83 @@
+package migratetests
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestEveryDriverHasTheSameMigrations(t *testing.T) {
+	postgres, err := migrate.Load(storage.PostgresDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	for _, driver := range []string{storage.MySQLDriver, storage.SQLiteDriver} {
+		list, err := migrate.Load(driver)
+		if err != nil {
+			t.Fatalf("this is the error loading the migrations of %s: %v\n", driver, err)
+		}
+		assert.Equal(t, len(list), len(postgres))
+		for i := range list {
+			assert.Equal(t, list[i].String(), postgres[i].String())
+		}
+	}
+
+	_, err = migrate.Load(storage.MemoryDriver)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestUpDownStatus(t *testing.T) {
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", storage.SQLiteMemory, storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+
+	err = migrator.Check()
+	assert.Equal(t, errors.Is(err, migrate.ErrBehind), true)
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+	assert.NotEqual(t, len(applied), 0)
+	assert.Equal(t, migrator.Check(), nil)
+	assert.Equal(t, db.HasTable(&models.Event{}), true)
+
+	// Applying again is a no-op
+	applied, err = migrator.Up()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(applied), 0)
+
+	reverted, err := migrator.Down()
+	if err != nil {
+		t.Fatalf("this is the error reverting the migration: %v\n", err)
+	}
+	assert.Equal(t, reverted.String(), "0003_create_idempotency_keys")
+	assert.Equal(t, db.HasTable(&models.IdempotencyKey{}), false)
+
+	status, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("this is the error reading the status: %v\n", err)
+	}
+	assert.Equal(t, status[0].Applied, true)
+	assert.Equal(t, status[len(status)-1].Applied, false)
+	assert.Equal(t, errors.Is(migrator.Check(), migrate.ErrBehind), true)
+
+	for reverted != nil {
+		reverted, err = migrator.Down()
+		if err != nil {
+			t.Fatalf("this is the error reverting the migration: %v\n", err)
+		}
+	}
+	assert.Equal(t, db.HasTable(&models.User{}), false)
+}
@@ -0,0 +1,199 @@
+package ratelimittests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
+)
+
+const checkMethod = "/grpc.health.v1.Health/Check"
+
+func TestMemoryStore(t *testing.T) {
+	store := ratelimit.NewMemoryStore(ratelimit.DefaultMaxBuckets)
+	limit := ratelimit.Limit{Rate: 10, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := store.Take(context.Background(), "a", limit)
+		if err != nil {
+			t.Fatalf("this is the error taking a token: %v\n", err)
+		}
+		assert.Equal(t, ok, true)
+	}
+	ok, retryAfter, _ := store.Take(context.Background(), "a", limit)
+	assert.Equal(t, ok, false)
+	assert.Equal(t, retryAfter > 0 && retryAfter <= 100*time.Millisecond, true)
+
+	// Buckets are per key
+	ok, _, _ = store.Take(context.Background(), "b", limit)
+	assert.Equal(t, ok, true)
+
+	// and refill at the rate
+	time.Sleep(retryAfter)
+	ok, _, _ = store.Take(context.Background(), "a", limit)
+	assert.Equal(t, ok, true)
+}
+
+func TestMemoryStoreMaxBuckets(t *testing.T) {
+	store := ratelimit.NewMemoryStore(2)
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 1}
+
+	store.Take(context.Background(), "a", limit)
+	store.Take(context.Background(), "b", limit)
+	// c evicts a, the least recently used bucket
+	store.Take(context.Background(), "c", limit)
+	assert.Equal(t, store.Len(), 2)
+
+	ok, _, _ := store.Take(context.Background(), "b", limit)
+	assert.Equal(t, ok, false)
+	ok, _, _ = store.Take(context.Background(), "a", limit)
+	assert.Equal(t, ok, true)
+}
+
+// serve serves the health service and an unimplemented user service behind
+// the rate limiter, trusting the gateway connecting over loopback.
+func serve(t *testing.T, limits ratelimit.Limits) *grpc.ClientConn {
+	store := ratelimit.NewMemoryStore(ratelimit.DefaultMaxBuckets)
+	loopback, err := ratelimit.Networks([]string{"127.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("this is the error parsing the networks: %v\n", err)
+	}
+	key := ratelimit.DefaultKey(nil, loopback, ratelimit.APIKeys([]string{"first", "second"}))
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(ratelimit.UnaryServerInterceptor(store, limits, key)),
+		grpc.ChainStreamInterceptor(ratelimit.StreamServerInterceptor(store, limits, key)),
+	)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	pbUser.RegisterUserServiceServer(s, &pbUser.UnimplementedUserServiceServer{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := gateway.Dial(context.Background(), lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("this is the error dialing: %v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestDefaultKeyTrustedProxies(t *testing.T) {
+	proxies, err := ratelimit.Networks([]string{"10.0.0.0/8", "::1/128"})
+	if err != nil {
+		t.Fatalf("this is the error parsing the networks: %v\n", err)
+	}
+	_, err = ratelimit.Networks([]string{"10.0.0.1"})
+	assert.NotEqual(t, err, nil)
+
+	tests := []struct {
+		name           string
+		trustedNetwork func(net.IP) bool
+		peer           string
+		want           string
+	}{
+		{"trusted proxy", proxies, "10.1.2.3", "ip:198.51.100.1"},
+		{"trusted loopback", proxies, "::1", "ip:198.51.100.1"},
+		{"untrusted peer", proxies, "192.0.2.1", "ip:192.0.2.1"},
+		{"loopback untrusted by default", nil, "127.0.0.1", "ip:127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(tt.peer), Port: 1234}})
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "203.0.113.1, 198.51.100.1"))
+			assert.Equal(t, ratelimit.DefaultKey(nil, tt.trustedNetwork, nil)(ctx), tt.want)
+		})
+	}
+}
+
+func TestResourceExhausted(t *testing.T) {
+	conn := serve(t, ratelimit.Limits{
+		Methods: map[string]ratelimit.Limit{checkMethod: {Rate: 0.5, Burst: 1}},
+	})
+	client := healthpb.NewHealthClient(conn)
+
+	// API keys have a bucket each
+	first := metadata.AppendToOutgoingContext(context.Background(), ratelimit.APIKeyKey, "first")
+	second := metadata.AppendToOutgoingContext(context.Background(), ratelimit.APIKeyKey, "second")
+
+	_, err := client.Check(first, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, err, nil)
+	_, err = client.Check(first, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = d
+		}
+	}
+	if retry == nil {
+		t.Fatalf("this is the error finding the retry info: %v\n", err)
+	}
+	assert.Equal(t, retry.GetRetryDelay().GetSeconds(), int64(2))
+
+	_, err = client.Check(second, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, err, nil)
+
+	// Unknown keys are ignored, their clients sharing the bucket of their IP
+	for i, code := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		madeUp := metadata.AppendToOutgoingContext(context.Background(), ratelimit.APIKeyKey, fmt.Sprintf("made-up-%d", i))
+		_, err = client.Check(madeUp, &healthpb.HealthCheckRequest{})
+		assert.Equal(t, status.Code(err), code)
+	}
+
+	// Methods without a limit are not limited
+	_, err = client.Watch(first, &healthpb.HealthCheckRequest{})
+	assert.Equal(t, err, nil)
+}
+
+func TestGatewayTooManyRequests(t *testing.T) {
+	conn := serve(t, ratelimit.Limits{
+		Default: ratelimit.Limit{Rate: 1, Burst: 1},
+	})
+	gw, err := gateway.New(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("this is the error creating the gateway: %v\n", err)
+	}
+
+	get := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		gw.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, get("192.0.2.1:1234", "").Code, http.StatusNotImplemented)
+
+	w := get("192.0.2.1:1234", "")
+	assert.Equal(t, w.Code, http.StatusTooManyRequests)
+	assert.Equal(t, w.Header().Get("Retry-After"), "1")
+
+	// Clients are told apart by the address the gateway received them from,
+	// not by the X-Forwarded-For they send
+	assert.Equal(t, get("192.0.2.1:1234", "198.51.100.1").Code, http.StatusTooManyRequests)
+	assert.Equal(t, get("192.0.2.2:1234", "").Code, http.StatusNotImplemented)
+}
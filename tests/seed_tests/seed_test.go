@@ -0,0 +1,155 @@
+package seedtests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/seed"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func repositories() (storage.UserRepository, storage.EventRepository, storage.UnitOfWork) {
+	mem := storage.NewMemory()
+	return storage.NewMemoryUserRepository(mem), storage.NewMemoryEventRepository(mem), storage.NewMemoryUnitOfWork(mem)
+}
+
+func TestSetsAreIdempotent(t *testing.T) {
+	users, events, work := repositories()
+	ctx := context.Background()
+
+	result, err := seed.Run(ctx, "../../fixtures", []string{"demo", "test"}, work)
+	if err != nil {
+		t.Fatalf("this is the error seeding the fixtures: %v\n", err)
+	}
+	assert.Equal(t, result, seed.Result{Created: 8})
+
+	result, err = seed.Run(ctx, "../../fixtures", []string{"demo", "test"}, work)
+	if err != nil {
+		t.Fatalf("this is the error seeding the fixtures again: %v\n", err)
+	}
+	assert.Equal(t, result, seed.Result{Unchanged: 8})
+
+	event, err := events.FindByTitle(ctx, "Title 2")
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	author, err := users.Get(ctx, event.AuthorID)
+	if err != nil {
+		t.Fatalf("this is the error getting the author: %v\n", err)
+	}
+	assert.Equal(t, author.Email, "luther@gmail.com")
+	assert.Equal(t, models.VerifyPassword(author.Password, "password"), nil)
+}
+
+func TestUpsertByNaturalKey(t *testing.T) {
+	users, events, _ := repositories()
+	ctx := context.Background()
+
+	f := seed.Fixtures{
+		Users:  []seed.User{{Nickname: "sam", Email: "sam@gmail.com", Password: "password"}},
+		Events: []seed.Event{{Title: "Sam event", Content: "Hello", Author: "sam@gmail.com"}},
+	}
+	if _, err := seed.Apply(ctx, f, users, events); err != nil {
+		t.Fatalf("this is the error seeding the fixtures: %v\n", err)
+	}
+
+	f.Users[0].Nickname = "samuel"
+	f.Events[0].Content = "Hello again"
+	result, err := seed.Apply(ctx, f, users, events)
+	if err != nil {
+		t.Fatalf("this is the error seeding the fixtures again: %v\n", err)
+	}
+	assert.Equal(t, result, seed.Result{Updated: 2})
+
+	user, err := users.FindByEmail(ctx, "sam@gmail.com")
+	if err != nil {
+		t.Fatalf("this is the error getting the user: %v\n", err)
+	}
+	assert.Equal(t, user.Nickname, "samuel")
+	event, err := events.FindByTitle(ctx, "Sam event")
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	assert.Equal(t, event.Content, "Hello again")
+
+	// Authors must be seeded
+	f.Events[0].Author = "nobody@gmail.com"
+	_, err = seed.Apply(ctx, f, users, events)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGenerate(t *testing.T) {
+	users, events, _ := repositories()
+
+	f := seed.Fixtures{Generate: []seed.Generate{{Prefix: "load", Users: 3, EventsPerUser: 2, Password: "load"}}}
+	result, err := seed.Apply(context.Background(), f, users, events)
+	if err != nil {
+		t.Fatalf("this is the error seeding the fixtures: %v\n", err)
+	}
+	assert.Equal(t, result, seed.Result{Created: 9})
+
+	event, err := events.FindByTitle(context.Background(), "load-event-3-2")
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	author, _ := users.Get(context.Background(), event.AuthorID)
+	assert.Equal(t, author.Email, "load-user-3@example.com")
+}
+
+func TestInvalidFixtures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "broken"), 0700); err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	write := func(content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, "broken", "broken.yaml"), []byte(content), 0600); err != nil {
+			t.Fatalf("this is the error writing the fixtures: %v\n", err)
+		}
+	}
+
+	_, err = seed.LoadSet(dir, "missing")
+	assert.NotEqual(t, err, nil)
+	_, err = seed.LoadSet(dir, "../broken")
+	assert.NotEqual(t, err, nil)
+
+	write("users:\n  - nickname: sam\n    mail: sam@gmail.com\n")
+	_, err = seed.LoadSet(dir, "broken")
+	assert.NotEqual(t, err, nil)
+
+	write("users:\n  - nickname: sam\n    email: sam@gmail.com\n")
+	_, err = seed.LoadSet(dir, "broken")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestFailedSetIsRolledBack(t *testing.T) {
+	users, _, work := repositories()
+
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.Mkdir(filepath.Join(dir, "orphan"), 0700); err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	content := "users:\n  - nickname: sam\n    email: sam@gmail.com\n    password: password\nevents:\n  - title: Orphan\n    content: Orphan\n    author: nobody@gmail.com\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "orphan", "orphan.yaml"), []byte(content), 0600); err != nil {
+		t.Fatalf("this is the error writing the fixtures: %v\n", err)
+	}
+
+	result, err := seed.Run(context.Background(), dir, []string{"orphan"}, work)
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, result, seed.Result{})
+	_, err = users.FindByEmail(context.Background(), "sam@gmail.com")
+	assert.Equal(t, err, storage.ErrNotFound)
+}
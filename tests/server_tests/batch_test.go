@@ -0,0 +1,238 @@
+package servertests
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+)
+
+// count returns the number of users and events stored.
+func count(t *testing.T) (users, events int) {
+	err := userRepository.List(context.Background(), func(*models.User) error {
+		users++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("this is the error listing the users: %v\n", err)
+	}
+	err = eventRepository.List(context.Background(), func(*models.Event) error {
+		events++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("this is the error listing the events: %v\n", err)
+	}
+	return users, events
+}
+
+func addUsers(emails ...string) []*pbUser.AddUserRequest {
+	users := make([]*pbUser.AddUserRequest, len(emails))
+	for i, email := range emails {
+		users[i] = &pbUser.AddUserRequest{Nickname: email, Email: email, Password: "password"}
+	}
+	return users
+}
+
+func TestBatches(t *testing.T) {
+	// Every batch runs against one stored user and their event
+	type batch func(ctx context.Context, b *server.Backend, seeded models.Event, bestEffort bool) ([]*spb.Status, error)
+
+	batchAddUsers := func(emails ...string) batch {
+		return func(ctx context.Context, b *server.Backend, _ models.Event, bestEffort bool) ([]*spb.Status, error) {
+			resp, err := b.BatchAddUsers(ctx, &pbUser.BatchAddUsersRequest{Users: addUsers(emails...), BestEffort: bestEffort})
+			var statuses []*spb.Status
+			for _, r := range resp.GetResults() {
+				statuses = append(statuses, r.Status)
+			}
+			return statuses, err
+		}
+	}
+	// batchAddEvents adds an event per author, 0 standing for the seeded one
+	// and -1 for the seeded one with a title too long once escaped
+	batchAddEvents := func(authorIDs ...int32) batch {
+		return func(ctx context.Context, b *server.Backend, seeded models.Event, bestEffort bool) ([]*spb.Status, error) {
+			events := make([]*pbEvent.AddEventRequest, len(authorIDs))
+			for i, id := range authorIDs {
+				title := fmt.Sprintf("Batched %d", i)
+				if id == -1 {
+					title = strings.Repeat("<", 100)
+				}
+				if id <= 0 {
+					id = int32(seeded.AuthorID)
+				}
+				events[i] = &pbEvent.AddEventRequest{Title: title, Content: "content", AuthorID: id}
+			}
+			resp, err := b.BatchAddEvents(ctx, &pbEvent.BatchAddEventsRequest{Events: events, BestEffort: bestEffort})
+			var statuses []*spb.Status
+			for _, r := range resp.GetResults() {
+				statuses = append(statuses, r.Status)
+			}
+			return statuses, err
+		}
+	}
+	// batchDeleteEvents deletes events by ID, 0 standing for the seeded one
+	batchDeleteEvents := func(ids ...int64) batch {
+		return func(ctx context.Context, b *server.Backend, seeded models.Event, bestEffort bool) ([]*spb.Status, error) {
+			events := make([]*pbEvent.DeleteEventRequest, len(ids))
+			for i, id := range ids {
+				if id == 0 {
+					id = int64(seeded.ID)
+				}
+				events[i] = &pbEvent.DeleteEventRequest{EventId: id, AuthorId: int64(seeded.AuthorID)}
+			}
+			resp, err := b.BatchDeleteEvents(ctx, &pbEvent.BatchDeleteEventsRequest{Events: events, BestEffort: bestEffort})
+			var statuses []*spb.Status
+			for _, r := range resp.GetResults() {
+				statuses = append(statuses, r.Status)
+			}
+			return statuses, err
+		}
+	}
+
+	tests := []struct {
+		name       string
+		batch      batch
+		bestEffort bool
+		// wantCode and wantMessage are the status of the RPC, wantStatuses
+		// those of its items
+		wantCode     codes.Code
+		wantMessage  string
+		wantStatuses []codes.Code
+		// wantUsers and wantEvents are the numbers stored afterwards
+		wantUsers, wantEvents int
+	}{
+		{
+			name:         "add users",
+			batch:        batchAddUsers("pet@gmail.com", "max@gmail.com"),
+			wantStatuses: []codes.Code{codes.OK, codes.OK},
+			wantUsers:    3, wantEvents: 1,
+		},
+		{
+			name:        "add users rolled back",
+			batch:       batchAddUsers("pet@gmail.com", "pet@gmail.com"),
+			wantCode:    codes.AlreadyExists,
+			wantMessage: "Users[1]: ",
+			wantUsers:   1, wantEvents: 1,
+		},
+		{
+			name:         "add users best effort",
+			batch:        batchAddUsers("pet@gmail.com", "pet@gmail.com"),
+			bestEffort:   true,
+			wantStatuses: []codes.Code{codes.OK, codes.AlreadyExists},
+			wantUsers:    2, wantEvents: 1,
+		},
+		{
+			name:        "too many users",
+			batch:       batchAddUsers("pet@gmail.com", "max@gmail.com", "tom@gmail.com"),
+			bestEffort:  true,
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "Users: at most 2 items",
+			wantUsers:   1, wantEvents: 1,
+		},
+		{
+			name:         "add events",
+			batch:        batchAddEvents(0, 0),
+			wantStatuses: []codes.Code{codes.OK, codes.OK},
+			wantUsers:    1, wantEvents: 3,
+		},
+		{
+			name:        "add events rolled back",
+			batch:       batchAddEvents(0, 999),
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "Events[1]: ",
+			wantUsers:   1, wantEvents: 1,
+		},
+		{
+			name:         "add events best effort",
+			batch:        batchAddEvents(999, 0),
+			bestEffort:   true,
+			wantStatuses: []codes.Code{codes.FailedPrecondition, codes.OK},
+			wantUsers:    1, wantEvents: 2,
+		},
+		{
+			name:         "add events too long once escaped",
+			batch:        batchAddEvents(-1, 0),
+			bestEffort:   true,
+			wantStatuses: []codes.Code{codes.InvalidArgument, codes.OK},
+			wantUsers:    1, wantEvents: 2,
+		},
+		{
+			name:        "too many events",
+			batch:       batchAddEvents(0, 0, 0),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "Events: at most 2 items",
+			wantUsers:   1, wantEvents: 1,
+		},
+		{
+			name:         "delete events",
+			batch:        batchDeleteEvents(0),
+			wantStatuses: []codes.Code{codes.OK},
+			wantUsers:    1, wantEvents: 0,
+		},
+		{
+			name:        "delete events rolled back",
+			batch:       batchDeleteEvents(0, 999),
+			wantCode:    codes.NotFound,
+			wantMessage: "Events[1]: ",
+			wantUsers:   1, wantEvents: 1,
+		},
+		{
+			name:         "delete events best effort",
+			batch:        batchDeleteEvents(999, 0),
+			bestEffort:   true,
+			wantStatuses: []codes.Code{codes.NotFound, codes.OK},
+			wantUsers:    1, wantEvents: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeded, err := seedOneUserAndOneEvent()
+			if err != nil {
+				log.Fatalf("Cannot seed user and event: %v\n", err)
+			}
+			backend := server.New(userRepository, eventRepository, unitOfWork)
+			backend.MaxBatchSize = 2
+
+			statuses, err := tt.batch(context.Background(), backend, seeded, tt.bestEffort)
+			st := status.Convert(err)
+			assert.Equal(t, st.Code(), tt.wantCode)
+			assert.Equal(t, strings.HasPrefix(st.Message(), tt.wantMessage), true)
+			if len(statuses) != len(tt.wantStatuses) {
+				t.Fatalf("this is the unexpected number of statuses: %d\n", len(statuses))
+			}
+			for i, want := range tt.wantStatuses {
+				assert.Equal(t, codes.Code(statuses[i].GetCode()), want)
+			}
+
+			users, events := count(t)
+			assert.Equal(t, users, tt.wantUsers)
+			assert.Equal(t, events, tt.wantEvents)
+		})
+	}
+}
+
+func TestBatchTooLargeViolation(t *testing.T) {
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+	backend.MaxBatchSize = 1
+
+	_, err := backend.BatchAddUsers(context.Background(), &pbUser.BatchAddUsersRequest{Users: addUsers("pet@gmail.com", "max@gmail.com")})
+	st := status.Convert(err)
+	assert.Equal(t, st.Code(), codes.InvalidArgument)
+	assert.Equal(t, len(st.Details()), 1)
+	violations := st.Details()[0].(*errdetails.BadRequest).FieldViolations
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].Field, "Users")
+}
@@ -0,0 +1,232 @@
+package servertests
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestConcurrentWrites(t *testing.T) {
+
+	event, err := seedOneUserAndOneEvent()
+	if err != nil {
+		log.Fatalf("Cannot seed user and event: %v\n", err)
+	}
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+	ctx := context.Background()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, err := backend.AddEvent(ctx, &pbEvent.AddEventRequest{
+				Title:    fmt.Sprintf("Concurrent %d", i),
+				Content:  "content",
+				AuthorID: int32(event.AuthorID),
+			})
+			errs <- err
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_, err := backend.UpdateEvent(ctx, &pbEvent.UpdateEventRequest{
+				ID:      int64(event.ID),
+				Content: fmt.Sprintf("content %d", i),
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("this is the error of a concurrent write: %v\n", err)
+		}
+	}
+
+	count := 0
+	err = eventRepository.List(ctx, func(*models.Event) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("this is the error listing the events: %v\n", err)
+	}
+	assert.Equal(t, count, writers+1)
+}
+
+// BenchmarkAddEventParallel measures the throughput of concurrent AddEvent
+// calls, run it with -cpu to vary the number of clients:
+//
+//	go test ./tests/server_tests -run NONE -bench AddEventParallel -cpu 1,4,16
+func BenchmarkAddEventParallel(b *testing.B) {
+
+	event, err := seedOneUserAndOneEvent()
+	if err != nil {
+		log.Fatalf("Cannot seed user and event: %v\n", err)
+	}
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+
+	var n int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := backend.AddEvent(context.Background(), &pbEvent.AddEventRequest{
+				Title:    fmt.Sprintf("Benchmark %d", atomic.AddInt64(&n, 1)),
+				Content:  "content",
+				AuthorID: int32(event.AuthorID),
+			})
+			if err != nil {
+				b.Errorf("this is the error adding the event: %v\n", err)
+				return
+			}
+		}
+	})
+}
+
+// watchStream collects the changes WatchEvents sends.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	changes chan *pbEvent.EventChange
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(change *pbEvent.EventChange) error {
+	s.changes <- change
+	return nil
+}
+
+// slowEvents delays the updates once committed, widening the window in which
+// concurrent updates could be published out of order.
+type slowEvents struct {
+	storage.EventRepository
+}
+
+func (r slowEvents) Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error) {
+	updated, err := r.EventRepository.Update(ctx, id, e)
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	return updated, err
+}
+
+func TestConcurrentUpdatesAreWatchedInOrder(t *testing.T) {
+
+	user, err := seedOneUser()
+	if err != nil {
+		log.Fatalf("Cannot seed user: %v\n", err)
+	}
+	backend := server.New(userRepository, slowEvents{eventRepository}, unitOfWork)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	event, err := backend.AddEvent(ctx, &pbEvent.AddEventRequest{Title: "Watched", Content: "content", AuthorID: int32(user.ID)})
+	if err != nil {
+		t.Fatalf("this is the error adding the event: %v\n", err)
+	}
+
+	// Resuming after the creation, the updates made before the watcher is
+	// registered are replayed
+	const writers = 50
+	stream := &watchStream{ctx: ctx, changes: make(chan *pbEvent.EventChange, writers)}
+	go backend.WatchEvents(&pbEvent.WatchEventsRequest{StartRevision: 1}, stream)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := backend.UpdateEvent(ctx, &pbEvent.UpdateEventRequest{ID: event.ID, Content: fmt.Sprintf("content %d", i)})
+			if err != nil {
+				t.Errorf("this is the error updating the event: %v\n", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var last *pbEvent.EventChange
+	for i := 0; i < writers; i++ {
+		change := <-stream.changes
+		if last != nil && change.Revision <= last.Revision {
+			t.Fatalf("revision %d received after %d\n", change.Revision, last.Revision)
+		}
+		last = change
+	}
+	stored, err := eventRepository.Get(ctx, uint64(event.ID))
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	// Watchers end up on the committed state
+	assert.Equal(t, last.Event.Content, stored.Content)
+}
+
+// blockedEvents holds the updates until released, as a slow write would.
+type blockedEvents struct {
+	storage.EventRepository
+	updating chan struct{}
+	release  chan struct{}
+}
+
+func (r blockedEvents) Update(ctx context.Context, id uint64, e *models.Event) (*models.Event, error) {
+	close(r.updating)
+	<-r.release
+	return r.EventRepository.Update(ctx, id, e)
+}
+
+func TestDeleteUserDoesNotWaitForEventsOfOthers(t *testing.T) {
+
+	if err := refreshUserAndEventTable(); err != nil {
+		log.Fatalf("Cannot refresh user and event tables: %v\n", err)
+	}
+	users, events, err := seedUsersAndEvents()
+	if err != nil {
+		log.Fatalf("Cannot seed users and events: %v\n", err)
+	}
+	blocked := blockedEvents{eventRepository, make(chan struct{}), make(chan struct{})}
+	backend := server.New(userRepository, blocked, unitOfWork)
+	ctx := context.Background()
+
+	updated := make(chan error)
+	go func() {
+		_, err := backend.UpdateEvent(ctx, &pbEvent.UpdateEventRequest{ID: int64(events[1].ID), Content: "updated"})
+		updated <- err
+	}()
+	<-blocked.updating
+
+	deleted := make(chan error)
+	go func() {
+		_, err := backend.DeleteUser(ctx, &pbUser.DeleteUserRequest{UserId: int32(users[0].ID)})
+		deleted <- err
+	}()
+	select {
+	case err := <-deleted:
+		if err != nil {
+			t.Fatalf("this is the error deleting the user: %v\n", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the deletion waited for the update of an event of another user\n")
+	}
+
+	close(blocked.release)
+	if err := <-updated; err != nil {
+		t.Fatalf("this is the error updating the event: %v\n", err)
+	}
+}
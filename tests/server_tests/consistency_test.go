@@ -0,0 +1,76 @@
+package servertests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/ratelimit"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func openSQLite(t *testing.T, path string) *gorm.DB {
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", path, storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+	return db
+}
+
+func TestReadYourWrites(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replicas")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The replica never gets the writes of the primary, as if it lagged forever
+	primary := openSQLite(t, filepath.Join(dir, "primary.db"))
+	defer primary.Close()
+	replica := openSQLite(t, filepath.Join(dir, "replica.db"))
+	defer replica.Close()
+
+	backend := server.New(storage.NewGormUserRepository(primary, replica), storage.NewGormEventRepository(primary, replica), storage.NewGormUnitOfWork(primary))
+	backend.ReadYourWrites = time.Minute
+	backend.ClientKey = ratelimit.DefaultKey(nil, nil, ratelimit.APIKeys([]string{"writer", "reader"}))
+
+	writer := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.APIKeyKey, "writer"))
+	reader := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.APIKeyKey, "reader"))
+
+	user, err := backend.AddUser(writer, &pbUser.AddUserRequest{Nickname: "sam", Email: "sam@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error adding the user: %v\n", err)
+	}
+
+	found, err := backend.GetUser(writer, &pbUser.GetUserRequest{UserId: user.ID})
+	if err != nil {
+		t.Fatalf("this is the error getting the user back: %v\n", err)
+	}
+	assert.Equal(t, found.Email, "sam@gmail.com")
+
+	_, err = backend.GetUser(reader, &pbUser.GetUserRequest{UserId: user.ID})
+	assert.Equal(t, status.Code(err), codes.NotFound)
+
+	strong := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ratelimit.APIKeyKey, "reader", server.ConsistencyKey, server.ConsistencyStrong))
+	_, err = backend.GetUser(strong, &pbUser.GetUserRequest{UserId: user.ID})
+	assert.Equal(t, err, nil)
+}
@@ -0,0 +1,80 @@
+package servertests
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+// brokenEvents fails to read events as a lost database would.
+type brokenEvents struct {
+	storage.EventRepository
+}
+
+func (brokenEvents) Get(ctx context.Context, id uint64) (*models.Event, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestErrorStatuses(t *testing.T) {
+
+	event, err := seedOneUserAndOneEvent()
+	if err != nil {
+		log.Fatalf("Cannot seed user and event: %v\n", err)
+	}
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+	broken := server.New(userRepository, brokenEvents{eventRepository}, unitOfWork)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		call        func() error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"too long once escaped", func() error {
+			// Valid, but 500 characters once escaped
+			_, err := backend.AddEvent(context.Background(), &pbEvent.AddEventRequest{Title: strings.Repeat("&", 100), Content: "content", AuthorID: int32(event.AuthorID)})
+			return err
+		}, codes.InvalidArgument, "Title: at most 255 characters are allowed once HTML escaped"},
+		{"not found", func() error {
+			_, err := backend.GetUser(context.Background(), &pbUser.GetUserRequest{UserId: 42})
+			return err
+		}, codes.NotFound, "user not found"},
+		{"already exists", func() error {
+			_, err := backend.AddEvent(context.Background(), &pbEvent.AddEventRequest{Title: event.Title, Content: "content", AuthorID: int32(event.AuthorID)})
+			return err
+		}, codes.AlreadyExists, "event already exists"},
+		{"missing reference", func() error {
+			_, err := backend.AddEvent(context.Background(), &pbEvent.AddEventRequest{Title: "Orphan", Content: "content", AuthorID: 42})
+			return err
+		}, codes.FailedPrecondition, "event references a missing record"},
+		{"canceled", func() error {
+			_, err := backend.BatchAddUsers(canceled, &pbUser.BatchAddUsersRequest{Users: addUsers("pet@gmail.com")})
+			return err
+		}, codes.Canceled, "Request canceled"},
+		{"internal", func() error {
+			_, err := broken.GetEvent(context.Background(), &pbEvent.GetEventRequest{EventId: int64(event.ID)})
+			return err
+		}, codes.Internal, "Error while accessing event in database"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			assert.Equal(t, st.Code(), tt.wantCode)
+			assert.Equal(t, st.Message(), tt.wantMessage)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package servertests
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -9,13 +10,16 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/joho/godotenv"
 
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
 	"github.com/RemyRanger/taktyl_core_grpc/src/models"
-	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
 )
 
-var backend = server.New()
-var userInstance = models.User{}
-var eventInstance = models.Event{}
+// db is only set when testing against a database
+var db *gorm.DB
+var userRepository storage.UserRepository
+var eventRepository storage.EventRepository
+var unitOfWork storage.UnitOfWork
 
 func TestMain(m *testing.M) {
 	var err error
@@ -34,24 +38,39 @@ func Database() {
 
 	TestDbDriver := os.Getenv("TestDbDriver")
 
-	if TestDbDriver == "postgres" {
-		DBURL := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s", os.Getenv("TestDbHost"), os.Getenv("TestDbPort"), os.Getenv("TestDbUser"), os.Getenv("TestDbName"), os.Getenv("TestDbPassword"))
-		backend.DB, err = gorm.Open(TestDbDriver, DBURL)
-		if err != nil {
-			fmt.Printf("Cannot connect to %s database\n", TestDbDriver)
-			log.Fatal("This is the error:", err)
-		} else {
-			fmt.Printf("We are connected to the %s database\n", TestDbDriver)
-		}
+	if TestDbDriver == storage.MemoryDriver {
+		fmt.Printf("We are using the %s storage\n", TestDbDriver)
+		refreshMemory()
+		return
 	}
+
+	db, err = storage.Open(TestDbDriver, os.Getenv("TestDbUser"), os.Getenv("TestDbPassword"), os.Getenv("TestDbPort"), os.Getenv("TestDbHost"), os.Getenv("TestDbName"), storage.Pool{})
+	if err != nil {
+		fmt.Printf("Cannot connect to %s database\n", TestDbDriver)
+		log.Fatal("This is the error:", err)
+	} else {
+		fmt.Printf("We are connected to the %s database\n", TestDbDriver)
+	}
+	userRepository = storage.NewGormUserRepository(db)
+	eventRepository = storage.NewGormEventRepository(db)
+	unitOfWork = storage.NewGormUnitOfWork(db)
+}
+
+// refreshMemory replaces the in-memory storage by an empty one.
+func refreshMemory() {
+	mem := storage.NewMemory()
+	userRepository = storage.NewMemoryUserRepository(mem)
+	eventRepository = storage.NewMemoryEventRepository(mem)
+	unitOfWork = storage.NewMemoryUnitOfWork(mem)
 }
 
 func refreshUserTable() error {
-	err := backend.DB.DropTableIfExists(&models.User{}).Error
-	if err != nil {
-		return err
+	if db == nil {
+		refreshMemory()
+		log.Printf("Successfully refreshed storage")
+		return nil
 	}
-	err = backend.DB.AutoMigrate(&models.User{}).Error
+	err := refreshSchema()
 	if err != nil {
 		return err
 	}
@@ -59,6 +78,26 @@ func refreshUserTable() error {
 	return nil
 }
 
+// refreshSchema reverts every migration of the test database then applies
+// them again.
+func refreshSchema() error {
+	migrator, err := migrate.New(db, os.Getenv("TestDbDriver"))
+	if err != nil {
+		return err
+	}
+	for {
+		reverted, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			break
+		}
+	}
+	_, err = migrator.Up()
+	return err
+}
+
 func seedOneUser() (models.User, error) {
 
 	err := refreshUserTable()
@@ -72,7 +111,7 @@ func seedOneUser() (models.User, error) {
 		Password: "password",
 	}
 
-	err = backend.DB.Model(&models.User{}).Create(&user).Error
+	_, err = userRepository.Create(context.Background(), &user)
 	if err != nil {
 		return models.User{}, err
 	}
@@ -98,7 +137,7 @@ func seedUsers() ([]models.User, error) {
 		},
 	}
 	for i := range users {
-		err := backend.DB.Model(&models.User{}).Create(&users[i]).Error
+		_, err := userRepository.Create(context.Background(), &users[i])
 		if err != nil {
 			return []models.User{}, err
 		}
@@ -107,12 +146,13 @@ func seedUsers() ([]models.User, error) {
 }
 
 func refreshUserAndEventTable() error {
-
-	err := backend.DB.DropTableIfExists(&models.User{}, &models.Event{}).Error
-	if err != nil {
-		return err
+	if db == nil {
+		refreshMemory()
+		log.Printf("Successfully refreshed storage")
+		return nil
 	}
-	err = backend.DB.AutoMigrate(&models.User{}, &models.Event{}).Error
+
+	err := refreshSchema()
 	if err != nil {
 		return err
 	}
@@ -131,7 +171,7 @@ func seedOneUserAndOneEvent() (models.Event, error) {
 		Email:    "sam@gmail.com",
 		Password: "password",
 	}
-	err = backend.DB.Model(&models.User{}).Create(&user).Error
+	_, err = userRepository.Create(context.Background(), &user)
 	if err != nil {
 		return models.Event{}, err
 	}
@@ -140,7 +180,7 @@ func seedOneUserAndOneEvent() (models.Event, error) {
 		Content:  "This is the content sam",
 		AuthorID: user.ID,
 	}
-	err = backend.DB.Model(&models.Event{}).Create(&event).Error
+	_, err = eventRepository.Create(context.Background(), &event)
 	if err != nil {
 		return models.Event{}, err
 	}
@@ -178,13 +218,13 @@ func seedUsersAndEvents() ([]models.User, []models.Event, error) {
 	}
 
 	for i := range users {
-		err = backend.DB.Model(&models.User{}).Create(&users[i]).Error
+		_, err = userRepository.Create(context.Background(), &users[i])
 		if err != nil {
 			log.Fatalf("cannot seed users table: %v", err)
 		}
 		events[i].AuthorID = users[i].ID
 
-		err = backend.DB.Model(&models.Event{}).Create(&events[i]).Error
+		_, err = eventRepository.Create(context.Background(), &events[i])
 		if err != nil {
 			log.Fatalf("cannot seed events table: %v", err)
 		}
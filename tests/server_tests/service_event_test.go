@@ -0,0 +1,190 @@
+package servertests
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/server"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestSaveEvent(t *testing.T) {
+
+	event, err := seedOneUserAndOneEvent()
+	if err != nil {
+		log.Fatalf("Cannot seed user and event: %v\n", err)
+	}
+
+	newEvent := models.Event{
+		Title:    "This is another title",
+		Content:  "This is another content",
+		AuthorID: event.AuthorID,
+	}
+	savedEvent, err := eventRepository.Create(context.Background(), &newEvent)
+	if err != nil {
+		t.Errorf("this is the error saving the event: %v\n", err)
+		return
+	}
+	assert.Equal(t, savedEvent.ID, event.ID+1)
+	assert.Equal(t, savedEvent.Title, newEvent.Title)
+	assert.Equal(t, savedEvent.AuthorID, event.AuthorID)
+
+	// Titles are unique
+	_, err = eventRepository.Create(context.Background(), &models.Event{Title: newEvent.Title, Content: "content", AuthorID: event.AuthorID})
+	assert.Equal(t, err, storage.ErrAlreadyExists)
+
+	// Authors must exist
+	_, err = eventRepository.Create(context.Background(), &models.Event{Title: "Orphan", Content: "content", AuthorID: event.AuthorID + 1})
+	assert.Equal(t, err, storage.ErrMissingReference)
+}
+
+func TestSaveUserUnique(t *testing.T) {
+
+	user, err := seedOneUser()
+	if err != nil {
+		log.Fatalf("Cannot seed user: %v\n", err)
+	}
+
+	_, err = userRepository.Create(context.Background(), &models.User{Nickname: "other", Email: user.Email, Password: "password"})
+	assert.Equal(t, err, storage.ErrAlreadyExists)
+
+	_, err = userRepository.Create(context.Background(), &models.User{Nickname: user.Nickname, Email: "other@gmail.com", Password: "password"})
+	assert.Equal(t, err, storage.ErrAlreadyExists)
+}
+
+func TestUnitOfWorkRollsBack(t *testing.T) {
+
+	event, err := seedOneUserAndOneEvent()
+	if err != nil {
+		log.Fatalf("Cannot seed user and event: %v\n", err)
+	}
+
+	// A failing step rolls the previous ones back
+	user := models.User{Nickname: "first", Email: "first@gmail.com", Password: "password"}
+	err = unitOfWork.Do(context.Background(), func(users storage.UserRepository, events storage.EventRepository) error {
+		if _, err := users.Create(context.Background(), &user); err != nil {
+			return err
+		}
+		if _, err := events.Delete(context.Background(), storage.EventRef{ID: event.ID, AuthorID: event.AuthorID}); err != nil {
+			return err
+		}
+		_, err := users.Create(context.Background(), &models.User{Nickname: "first", Email: "second@gmail.com", Password: "password"})
+		return err
+	})
+	assert.Equal(t, err, storage.ErrAlreadyExists)
+	_, err = userRepository.Get(context.Background(), user.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+	_, err = eventRepository.Get(context.Background(), event.ID)
+	assert.Equal(t, err, nil)
+
+	// So does a context done before the commit
+	ctx, cancel := context.WithCancel(context.Background())
+	err = unitOfWork.Do(ctx, func(users storage.UserRepository, events storage.EventRepository) error {
+		_, err := events.Update(ctx, event.ID, &models.Event{Content: "Canceled content"})
+		cancel()
+		return err
+	})
+	assert.Equal(t, err, context.Canceled)
+	unchanged, err := eventRepository.Get(context.Background(), event.ID)
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	assert.Equal(t, unchanged.Content, event.Content)
+
+	// And a successful one commits every step
+	err = unitOfWork.Do(context.Background(), func(users storage.UserRepository, events storage.EventRepository) error {
+		if _, err := users.Create(context.Background(), &user); err != nil {
+			return err
+		}
+		_, err := events.Create(context.Background(), &models.Event{Title: "First event", Content: "content", AuthorID: user.ID})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("this is the error of the unit of work: %v\n", err)
+	}
+	_, err = eventRepository.FindByTitle(context.Background(), "First event")
+	assert.Equal(t, err, nil)
+}
+
+func TestDeleteUserDeletesEvents(t *testing.T) {
+
+	err := refreshUserAndEventTable()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	users, events, err := seedUsersAndEvents()
+	if err != nil {
+		log.Fatalf("Cannot seed users and events: %v\n", err)
+	}
+
+	_, err = userRepository.Delete(context.Background(), users[0].ID)
+	if err != nil {
+		t.Errorf("this is the error deleting the user: %v\n", err)
+		return
+	}
+
+	_, err = eventRepository.Get(context.Background(), events[0].ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+	remaining, err := eventRepository.Get(context.Background(), events[1].ID)
+	if err != nil {
+		t.Errorf("this is the error getting the event: %v\n", err)
+		return
+	}
+	assert.Equal(t, remaining.AuthorID, users[1].ID)
+}
+
+func TestDeleteUserPublishesEventDeletions(t *testing.T) {
+
+	err := refreshUserAndEventTable()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	users, events, err := seedUsersAndEvents()
+	if err != nil {
+		log.Fatalf("Cannot seed users and events: %v\n", err)
+	}
+	backend := server.New(userRepository, eventRepository, unitOfWork)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	added, err := backend.AddEvent(ctx, &pbEvent.AddEventRequest{Title: "Title 3", Content: "Hello world 3", AuthorID: int32(users[0].ID)})
+	if err != nil {
+		t.Fatalf("this is the error adding the event: %v\n", err)
+	}
+	// Resuming after the creation
+	stream := &watchStream{ctx: ctx, changes: make(chan *pbEvent.EventChange, 3)}
+	go backend.WatchEvents(&pbEvent.WatchEventsRequest{StartRevision: 1}, stream)
+
+	_, err = backend.DeleteUser(ctx, &pbUser.DeleteUserRequest{UserId: int32(users[0].ID)})
+	if err != nil {
+		t.Fatalf("this is the error deleting the user: %v\n", err)
+	}
+
+	// The events deleted along with their author are watched deleted, not
+	// those of other authors
+	for _, id := range []int64{int64(events[0].ID), added.ID} {
+		var change *pbEvent.EventChange
+		select {
+		case change = <-stream.changes:
+		case <-time.After(time.Second):
+			t.Fatalf("the deletion of event %d is not watched\n", id)
+		}
+		assert.Equal(t, change.ChangeType, pbEvent.EventChange_DELETED)
+		assert.Equal(t, change.Event.ID, id)
+		assert.Equal(t, change.Event.AuthorID, int32(users[0].ID))
+	}
+	select {
+	case change := <-stream.changes:
+		t.Fatalf("this is the unexpected change: %v\n", change)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
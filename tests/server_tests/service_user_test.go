@@ -1,6 +1,7 @@
 package servertests
 
 import (
+	"context"
 	"log"
 	"testing"
 
@@ -21,7 +22,7 @@ func TestSaveUser(t *testing.T) {
 		Nickname: "test",
 		Password: "password",
 	}
-	savedUser, err := newUser.SaveUser(backend.DB)
+	savedUser, err := userRepository.Create(context.Background(), &newUser)
 	if err != nil {
 		t.Errorf("this is the error getting the users: %v\n", err)
 		return
@@ -42,7 +43,7 @@ func TestGetUserByID(t *testing.T) {
 	if err != nil {
 		log.Fatalf("cannot seed users table: %v", err)
 	}
-	foundUser, err := userInstance.FindUserByID(backend.DB, user.ID)
+	foundUser, err := userRepository.Get(context.Background(), user.ID)
 	if err != nil {
 		t.Errorf("this is the error getting one user: %v\n", err)
 		return
@@ -71,7 +72,7 @@ func TestUpdateAUser(t *testing.T) {
 		Email:    "modiupdate@gmail.com",
 		Password: "password",
 	}
-	updatedUser, err := userUpdate.UpdateAUser(server.DB, user.ID)
+	updatedUser, err := userRepository.Update(context.Background(), user.ID, &userUpdate)
 	if err != nil {
 		t.Errorf("this is the error updating the user: %v\n", err)
 		return
@@ -94,7 +95,7 @@ func TestDeleteAUser(t *testing.T) {
 		log.Fatalf("Cannot seed user: %v\n", err)
 	}
 
-	isDeleted, err := userInstance.DeleteAUser(backend.DB, user.ID)
+	isDeleted, err := userRepository.Delete(context.Background(), user.ID)
 	if err != nil {
 		t.Errorf("this is the error updating the user: %v\n", err)
 		return
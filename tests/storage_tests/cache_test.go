@@ -0,0 +1,169 @@
+package storagetests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/cache"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestCachedRepositories(t *testing.T) {
+	mem := storage.NewMemory()
+	rawUsers := storage.NewMemoryUserRepository(mem)
+	rawEvents := storage.NewMemoryEventRepository(mem)
+
+	lookups := map[string]int{}
+	lru := cache.NewLRU(100)
+	cached := storage.NewCached(lru, time.Minute)
+	cached.Observe = func(table, result string) { lookups[table+" "+result]++ }
+	users := cached.Users(rawUsers)
+	events := cached.Events(rawEvents)
+	work := cached.UnitOfWork(storage.NewMemoryUnitOfWork(mem))
+
+	ctx := context.Background()
+	user, err := users.Create(ctx, &models.User{Nickname: "sam", Email: "sam@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error creating the user: %v\n", err)
+	}
+	event, err := events.Create(ctx, &models.Event{Title: "Title", Content: "Content", AuthorID: user.ID})
+	if err != nil {
+		t.Fatalf("this is the error creating the event: %v\n", err)
+	}
+
+	// Writes made behind the cache are not seen until the record is dropped
+	users.Get(ctx, user.ID)
+	rawUsers.Update(ctx, user.ID, &models.User{Nickname: "behind", Email: "sam@gmail.com", Password: "password"})
+	found, _ := users.Get(ctx, user.ID)
+	assert.Equal(t, found.Nickname, "sam")
+	assert.Equal(t, lookups, map[string]int{"users miss": 1, "users hit": 1})
+
+	// Password hashes are not cached
+	value, _, _ := lru.Get(ctx, fmt.Sprintf("user:%d", user.ID))
+	assert.Equal(t, strings.Contains(string(value), `"password":""`), true)
+	assert.Equal(t, found.Password, "")
+
+	// Reads from the primary bypass the cache
+	found, _ = users.Get(storage.ReadPrimary(ctx), user.ID)
+	assert.Equal(t, found.Nickname, "behind")
+	assert.Equal(t, lookups["users bypass"], 1)
+
+	// Updates drop the record
+	if _, err := users.Update(ctx, user.ID, &models.User{Nickname: "samuel", Email: "sam@gmail.com", Password: "password"}); err != nil {
+		t.Fatalf("this is the error updating the user: %v\n", err)
+	}
+	found, _ = users.Get(ctx, user.ID)
+	assert.Equal(t, found.Nickname, "samuel")
+
+	// So do the writes of units of work
+	events.Get(ctx, event.ID)
+	err = work.Do(ctx, func(_ storage.UserRepository, events storage.EventRepository) error {
+		_, err := events.Update(ctx, event.ID, &models.Event{Title: "Title", Content: "Updated"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("this is the error updating the event: %v\n", err)
+	}
+	foundEvent, _ := events.Get(ctx, event.ID)
+	assert.Equal(t, foundEvent.Content, "Updated")
+
+	// Events deleted by author are dropped, and so is their deleted author
+	assert.Equal(t, lru.Len(), 2)
+	n, err := events.DeleteByAuthor(ctx, user.ID, func(*models.Event) error { return nil })
+	if err != nil {
+		t.Fatalf("this is the error deleting the events: %v\n", err)
+	}
+	assert.Equal(t, n, int64(1))
+	assert.Equal(t, lru.Len(), 1)
+	if _, err := users.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("this is the error deleting the user: %v\n", err)
+	}
+	assert.Equal(t, lru.Len(), 0)
+	_, err = users.Get(ctx, user.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+	_, err = events.Get(ctx, event.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+}
+
+func TestCachedUnitOfWorkDropsEventsOfDeletedUsers(t *testing.T) {
+	mem := storage.NewMemory()
+	lru := cache.NewLRU(100)
+	cached := storage.NewCached(lru, time.Minute)
+	users := cached.Users(storage.NewMemoryUserRepository(mem))
+	events := cached.Events(storage.NewMemoryEventRepository(mem))
+	work := cached.UnitOfWork(storage.NewMemoryUnitOfWork(mem))
+
+	ctx := context.Background()
+	user, err := users.Create(ctx, &models.User{Nickname: "sam", Email: "sam@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error creating the user: %v\n", err)
+	}
+	for _, title := range []string{"First", "Second"} {
+		event, err := events.Create(ctx, &models.Event{Title: title, Content: "Content", AuthorID: user.ID})
+		if err != nil {
+			t.Fatalf("this is the error creating the event: %v\n", err)
+		}
+		events.Get(ctx, event.ID)
+	}
+	assert.Equal(t, lru.Len(), 2)
+
+	err = work.Do(ctx, func(users storage.UserRepository, events storage.EventRepository) error {
+		if _, err := events.DeleteByAuthor(ctx, user.ID, func(*models.Event) error { return nil }); err != nil {
+			return err
+		}
+		_, err := users.Delete(ctx, user.ID)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("this is the error deleting the user: %v\n", err)
+	}
+	assert.Equal(t, lru.Len(), 0)
+}
+
+// pausedUsers holds the reads once done, until released.
+type pausedUsers struct {
+	storage.UserRepository
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (r pausedUsers) Get(ctx context.Context, id uint32) (*models.User, error) {
+	u, err := r.UserRepository.Get(ctx, id)
+	close(r.read)
+	<-r.release
+	return u, err
+}
+
+func TestCachedReadRacingWithWriteIsNotCached(t *testing.T) {
+	rawUsers := storage.NewMemoryUserRepository(storage.NewMemory())
+	cached := storage.NewCached(cache.NewLRU(100), time.Minute)
+	ctx := context.Background()
+	user, err := rawUsers.Create(ctx, &models.User{Nickname: "sam", Email: "sam@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error creating the user: %v\n", err)
+	}
+
+	// The read gets the user before the update, and caches them after
+	paused := pausedUsers{rawUsers, make(chan struct{}), make(chan struct{})}
+	read := make(chan *models.User)
+	go func() {
+		found, _ := cached.Users(paused).Get(ctx, user.ID)
+		read <- found
+	}()
+	<-paused.read
+	users := cached.Users(rawUsers)
+	if _, err := users.Update(ctx, user.ID, &models.User{Nickname: "samuel", Email: "sam@gmail.com", Password: "password"}); err != nil {
+		t.Fatalf("this is the error updating the user: %v\n", err)
+	}
+	close(paused.release)
+	assert.Equal(t, (<-read).Nickname, "sam")
+
+	found, _ := users.Get(ctx, user.ID)
+	assert.Equal(t, found.Nickname, "samuel")
+}
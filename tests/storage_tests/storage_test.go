@@ -0,0 +1,251 @@
+package storagetests
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/migrate"
+	"github.com/RemyRanger/taktyl_core_grpc/src/models"
+	"github.com/RemyRanger/taktyl_core_grpc/src/seed"
+	"github.com/RemyRanger/taktyl_core_grpc/src/storage"
+)
+
+func TestUnsupportedDriver(t *testing.T) {
+	_, err := storage.Open("oracle", "user", "password", "1521", "127.0.0.1", "db", storage.Pool{})
+	assert.NotEqual(t, err, nil)
+}
+
+func TestSQLiteCascades(t *testing.T) {
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", storage.SQLiteMemory, storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+
+	ctx := context.Background()
+	users := storage.NewGormUserRepository(db)
+	events := storage.NewGormEventRepository(db)
+	if _, err := seed.Run(ctx, "../../fixtures", []string{"demo"}, storage.NewGormUnitOfWork(db)); err != nil {
+		t.Fatalf("this is the error seeding the database: %v\n", err)
+	}
+
+	event, err := events.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+	deleted, err := users.Delete(ctx, event.AuthorID)
+	if err != nil {
+		t.Fatalf("this is the error deleting the user: %v\n", err)
+	}
+	assert.Equal(t, deleted, int64(1))
+
+	// The foreign key of the events table deletes the events of the user
+	_, err = events.Get(ctx, event.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+	_, err = events.Get(ctx, 2)
+	assert.Equal(t, err, nil)
+}
+
+func TestSQLiteReadsDoNotWaitForWrites(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", filepath.Join(dir, "taktyl.db"), storage.Pool{MaxOpenConns: 2})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+
+	ctx := context.Background()
+	users := storage.NewGormUserRepository(db)
+	user, err := users.Create(ctx, &models.User{Nickname: "sam", Email: "sam@gmail.com", Password: "password"})
+	if err != nil {
+		t.Fatalf("this is the error creating the user: %v\n", err)
+	}
+
+	// A read runs while a unit of work holds the write lock
+	read := make(chan error)
+	err = storage.NewGormUnitOfWork(db).Do(ctx, func(users storage.UserRepository, _ storage.EventRepository) error {
+		if _, err := users.Create(ctx, &models.User{Nickname: "pet", Email: "pet@gmail.com", Password: "password"}); err != nil {
+			return err
+		}
+		go func() {
+			_, err := storage.NewGormUserRepository(db).Get(ctx, user.ID)
+			read <- err
+		}()
+		select {
+		case err := <-read:
+			return err
+		case <-time.After(time.Second):
+			return errors.New("the read waited for the unit of work")
+		}
+	})
+	if err != nil {
+		t.Fatalf("this is the error of the unit of work: %v\n", err)
+	}
+}
+
+func TestGormDeleteByAuthor(t *testing.T) {
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", storage.SQLiteMemory, storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+
+	ctx := context.Background()
+	events := storage.NewGormEventRepository(db)
+	if _, err := seed.Run(ctx, "../../fixtures", []string{"demo"}, storage.NewGormUnitOfWork(db)); err != nil {
+		t.Fatalf("this is the error seeding the database: %v\n", err)
+	}
+	event, err := events.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("this is the error getting the event: %v\n", err)
+	}
+
+	// Nothing is deleted when fn fails
+	failed := errors.New("failed")
+	_, err = events.DeleteByAuthor(ctx, event.AuthorID, func(*models.Event) error { return failed })
+	assert.Equal(t, err, failed)
+	_, err = events.Get(ctx, event.ID)
+	assert.Equal(t, err, nil)
+
+	var listed []uint64
+	deleted, err := events.DeleteByAuthor(ctx, event.AuthorID, func(e *models.Event) error {
+		assert.Equal(t, e.AuthorID, event.AuthorID)
+		listed = append(listed, e.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("this is the error deleting the events: %v\n", err)
+	}
+	assert.Equal(t, deleted, int64(len(listed)))
+	assert.Equal(t, listed[0], event.ID)
+	_, err = events.Get(ctx, event.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+}
+
+func TestConnectRetries(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+	defer cancel()
+
+	// Nothing listens on port 1
+	var waits []time.Duration
+	_, err := storage.Connect(ctx, storage.PostgresDriver, "user", "password", "1", "127.0.0.1", "db", storage.Pool{},
+		func(err error, wait time.Duration) { waits = append(waits, wait) })
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, waits[:2], []time.Duration{250 * time.Millisecond, 500 * time.Millisecond})
+
+	// Settings that cannot work are not retried
+	waits = nil
+	_, err = storage.Connect(context.Background(), "oracle", "user", "password", "1521", "127.0.0.1", "db", storage.Pool{},
+		func(err error, wait time.Duration) { waits = append(waits, wait) })
+	assert.NotEqual(t, err, nil)
+	assert.Equal(t, len(waits), 0)
+}
+
+func TestPoolAndDeadlines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", filepath.Join(dir, "test.db"), storage.Pool{MaxOpenConns: 3, MaxIdleConns: 2})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	defer db.Close()
+	assert.Equal(t, db.DB().Stats().MaxOpenConnections, 3)
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+
+	// Queries stop with the context of the request
+	users := storage.NewGormUserRepository(db)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = users.Get(ctx, 1)
+	assert.Equal(t, errors.Is(err, context.DeadlineExceeded), true)
+}
+
+// openMigrated opens a migrated SQLite database in dir.
+func openMigrated(t *testing.T, dir, name string) *gorm.DB {
+	db, err := storage.Open(storage.SQLiteDriver, "", "", "", "", filepath.Join(dir, name), storage.Pool{})
+	if err != nil {
+		t.Fatalf("this is the error opening the database: %v\n", err)
+	}
+	migrator, err := migrate.New(db, storage.SQLiteDriver)
+	if err != nil {
+		t.Fatalf("this is the error loading the migrations: %v\n", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("this is the error migrating the database: %v\n", err)
+	}
+	return db
+}
+
+func TestReadReplicas(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatalf("this is the error creating a directory: %v\n", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The replica is not replicated, it never gets the writes of the primary
+	primary := openMigrated(t, dir, "primary.db")
+	defer primary.Close()
+	replica := openMigrated(t, dir, "replica.db")
+	defer replica.Close()
+
+	ctx := context.Background()
+	users := storage.NewGormUserRepository(primary, replica)
+	user := &models.User{Nickname: "sam", Email: "sam@gmail.com", Password: "password"}
+	if _, err := users.Create(ctx, user); err != nil {
+		t.Fatalf("this is the error creating the user: %v\n", err)
+	}
+
+	_, err = users.Get(ctx, user.ID)
+	assert.Equal(t, err, storage.ErrNotFound)
+	_, err = users.Get(storage.ReadPrimary(ctx), user.ID)
+	assert.Equal(t, err, nil)
+
+	// Reads fall back on the primary when the replica is down
+	replica.Close()
+	_, err = users.Get(ctx, user.ID)
+	assert.Equal(t, err, nil)
+}
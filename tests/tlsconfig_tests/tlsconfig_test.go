@@ -0,0 +1,201 @@
+package tlsconfigtests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+	"github.com/RemyRanger/taktyl_core_grpc/src/identity"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tlsconfig"
+)
+
+type keyPair struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issue creates a certificate for cn signed by parent, or self signed when
+// parent is nil.
+func issue(t *testing.T, cn string, parent *keyPair) *keyPair {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("this is the error generating a key: %v\n", err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer := &keyPair{cert: template, key: key}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer = parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.cert, &key.PublicKey, signer.key)
+	if err != nil {
+		t.Fatalf("this is the error creating a certificate: %v\n", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &keyPair{cert: cert, key: key}
+}
+
+func (kp *keyPair) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{kp.cert.Raw}, PrivateKey: kp.key, Leaf: kp.cert}
+}
+
+// write stores kp in dir as cert.pem and key.pem, with a modification time
+// distinct from the previous write.
+func (kp *keyPair) write(t *testing.T, dir string, modTime time.Time) tlsconfig.Files {
+	keyDER, err := x509.MarshalECPrivateKey(kp.key)
+	if err != nil {
+		t.Fatalf("this is the error marshaling a key: %v\n", err)
+	}
+	files := tlsconfig.Files{
+		CertFile: filepath.Join(dir, "cert.pem"),
+		KeyFile:  filepath.Join(dir, "key.pem"),
+	}
+	writeFile(t, files.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: kp.cert.Raw}), modTime)
+	writeFile(t, files.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), modTime)
+	return files
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("this is the error writing %s: %v\n", path, err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("this is the error touching %s: %v\n", path, err)
+	}
+}
+
+func servedCertificate(t *testing.T, r *tlsconfig.Reloader) *x509.Certificate {
+	cfg, err := r.ServerConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("this is the error getting the config: %v\n", err)
+	}
+	cert, _ := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	return cert
+}
+
+func TestReloadOnChange(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tlsconfig")
+	defer os.RemoveAll(dir)
+
+	first := issue(t, "first", nil)
+	files := first.write(t, dir, time.Now().Add(-time.Minute))
+	r, err := tlsconfig.NewReloader(files, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("this is the error loading the certificates: %v\n", err)
+	}
+	defer r.Close()
+	assert.Equal(t, servedCertificate(t, r).Subject.CommonName, "first")
+
+	// A half written rotation keeps the previous certificate
+	writeFile(t, files.CertFile, []byte("not a certificate"), time.Now())
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, servedCertificate(t, r).Subject.CommonName, "first")
+
+	second := issue(t, "second", nil)
+	second.write(t, dir, time.Now().Add(time.Minute))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, servedCertificate(t, r).Subject.CommonName, "second")
+	assert.Equal(t, r.IsOwnCertificate(second.cert), true)
+	assert.Equal(t, r.IsOwnCertificate(first.cert), false)
+}
+
+func TestMutualTLSIdentity(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "tlsconfig")
+	defer os.RemoveAll(dir)
+
+	ca := issue(t, "ca", nil)
+	files := issue(t, "server", ca).write(t, dir, time.Now())
+	files.CAFile = filepath.Join(dir, "ca.pem")
+	files.ClientAuth = true
+	writeFile(t, files.CAFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), time.Now())
+
+	certs, err := tlsconfig.NewReloader(files, time.Minute)
+	if err != nil {
+		t.Fatalf("this is the error loading the certificates: %v\n", err)
+	}
+	defer certs.Close()
+
+	var commonName string
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		identity.UnaryServerInterceptor(certs.IsOwnCertificate),
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			commonName = ""
+			if id, ok := identity.FromContext(ctx); ok {
+				commonName = id.CommonName
+			}
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	go gateway.NewServer(gateway.Multiplex(s, http.NotFoundHandler()), certs).Serve(lis)
+
+	check := func(cfg *tls.Config, md metadata.MD) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = healthpb.NewHealthClient(conn).Check(metadata.NewOutgoingContext(ctx, md), &healthpb.HealthCheckRequest{})
+		return err
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := issue(t, "client", ca)
+
+	err = check(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{client.tlsCertificate()}}, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, commonName, "client")
+
+	// Only the server's own certificate may forward the identity of a client
+	forwarded := metadata.Pairs(identity.ForwardedCertKey, string(client.cert.Raw))
+	err = check(certs.ClientConfig(), forwarded)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, commonName, "client")
+
+	other := issue(t, "other", ca)
+	err = check(&tls.Config{RootCAs: roots, Certificates: []tls.Certificate{other.tlsCertificate()}}, forwarded)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, commonName, "other")
+
+	err = check(&tls.Config{RootCAs: roots}, nil)
+	assert.NotEqual(t, err, nil)
+}
@@ -0,0 +1,127 @@
+package tracingtests
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gopkg.in/go-playground/assert.v1"
+
+	"github.com/RemyRanger/taktyl_core_grpc/src/gateway"
+	"github.com/RemyRanger/taktyl_core_grpc/src/tracing"
+)
+
+// recorder keeps the ended spans in memory.
+type recorder struct {
+	mu    sync.Mutex
+	spans []*exporttrace.SpanData
+}
+
+func (r *recorder) ExportSpans(_ context.Context, spans []*exporttrace.SpanData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *recorder) Shutdown(context.Context) error { return nil }
+
+func (r *recorder) find(name string, kind trace.SpanKind) *exporttrace.SpanData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, span := range r.spans {
+		if span.Name == name && span.SpanKind == kind {
+			return span
+		}
+	}
+	return nil
+}
+
+// setup records spans and serves the health service behind the gateway.
+func setup(t *testing.T) (*recorder, *grpc.ClientConn) {
+	if _, err := tracing.Setup(tracing.Options{}); err != nil {
+		t.Fatalf("this is the error setting tracing up: %v\n", err)
+	}
+	rec := &recorder{}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(rec)))
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor()))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("this is the error listening: %v\n", err)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := gateway.Dial(context.Background(), lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("this is the error dialing: %v\n", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rec, conn
+}
+
+func TestRPCSpans(t *testing.T) {
+	rec, conn := setup(t)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "root")
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	span.End()
+	if err != nil {
+		t.Fatalf("this is the error checking health: %v\n", err)
+	}
+
+	root := rec.find("root", trace.SpanKindInternal)
+	client := rec.find("grpc.health.v1.Health/Check", trace.SpanKindClient)
+	server := rec.find("grpc.health.v1.Health/Check", trace.SpanKindServer)
+	if root == nil || client == nil || server == nil {
+		t.Fatalf("this is the error finding the spans: %v\n", rec.spans)
+	}
+
+	// The server continues the trace of the client through the metadata
+	assert.Equal(t, client.ParentSpanID, root.SpanContext.SpanID)
+	assert.Equal(t, server.ParentSpanID, client.SpanContext.SpanID)
+	assert.Equal(t, server.SpanContext.TraceID, root.SpanContext.TraceID)
+}
+
+func TestGatewayContinuesTraceParent(t *testing.T) {
+	rec, conn := setup(t)
+
+	gw, err := gateway.New(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("this is the error creating the gateway: %v\n", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	gw.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := rec.find("HTTP GET", trace.SpanKindServer)
+	if span == nil {
+		t.Fatalf("this is the error finding the gateway span: %v\n", rec.spans)
+	}
+	assert.Equal(t, span.SpanContext.TraceID.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, span.ParentSpanID.String(), "00f067aa0ba902b7")
+	assert.Equal(t, span.HasRemoteParent, true)
+
+	// The proxied RPC is a child of the request
+	client := rec.find("user.UserService/GetUser", trace.SpanKindClient)
+	if client == nil {
+		t.Fatalf("this is the error finding the RPC span: %v\n", rec.spans)
+	}
+	assert.Equal(t, client.ParentSpanID, span.SpanContext.SpanID)
+}
@@ -0,0 +1,71 @@
+package validationtests
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/go-playground/assert.v1"
+
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	pbUser "github.com/RemyRanger/taktyl_core_grpc/src/proto/user"
+	"github.com/RemyRanger/taktyl_core_grpc/src/validation"
+)
+
+var info = &grpc.UnaryServerInfo{FullMethod: "/user.UserService/AddUser"}
+
+func handler(ctx context.Context, req interface{}) (interface{}, error) {
+	return req, nil
+}
+
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	st := status.Convert(err)
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			return br.GetFieldViolations()
+		}
+	}
+	t.Fatalf("no BadRequest detail in %v", err)
+	return nil
+}
+
+func TestValidRequestReachesHandler(t *testing.T) {
+	interceptor := validation.UnaryServerInterceptor()
+
+	req := &pbUser.AddUserRequest{Nickname: "pet", Email: "pet@gmail.com", Password: "password"}
+	resp, err := interceptor(context.Background(), req, info, handler)
+	if err != nil {
+		t.Fatalf("this is the error validating a valid user: %v\n", err)
+	}
+	assert.Equal(t, resp, req)
+}
+
+func TestInvalidRequests(t *testing.T) {
+	interceptor := validation.UnaryServerInterceptor()
+
+	samples := []struct {
+		req   interface{}
+		field string
+	}{
+		{&pbUser.AddUserRequest{Nickname: "", Email: "pet@gmail.com", Password: "password"}, "Nickname"},
+		{&pbUser.AddUserRequest{Nickname: "   ", Email: "pet@gmail.com", Password: "password"}, "Nickname"},
+		{&pbUser.AddUserRequest{Nickname: "pet", Email: "petgmail.com", Password: "password"}, "Email"},
+		{&pbUser.AddUserRequest{Nickname: "pet", Email: "pet@gmail.com", Password: ""}, "Password"},
+		{&pbUser.UpdateUserRequest{ID: 0, Nickname: "pet", Email: "pet@gmail.com", Password: "password"}, "ID"},
+		{&pbUser.GetUserRequest{UserId: 0}, "UserId"},
+		{&pbEvent.AddEventRequest{Title: "Title", Content: "", AuthorID: 1}, "Content"},
+		{&pbEvent.AddEventRequest{Title: "Title", Content: "Content", AuthorID: 0}, "AuthorID"},
+		{&pbEvent.DeleteEventRequest{EventId: 1, AuthorId: 0}, "AuthorId"},
+	}
+
+	for _, v := range samples {
+		_, err := interceptor(context.Background(), v.req, info, handler)
+		assert.Equal(t, status.Code(err), codes.InvalidArgument)
+		violations := fieldViolations(t, err)
+		assert.Equal(t, len(violations), 1)
+		assert.Equal(t, violations[0].GetField(), v.field)
+	}
+}
@@ -0,0 +1,106 @@
+package watchtests
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+
+	pbEvent "github.com/RemyRanger/taktyl_core_grpc/src/proto/event"
+	"github.com/RemyRanger/taktyl_core_grpc/src/watch"
+)
+
+func publishEvents(hub *watch.Hub, authors ...int32) {
+	for i, author := range authors {
+		hub.Publish(pbEvent.EventChange_CREATED, &pbEvent.EventDTO{ID: int64(i + 1), AuthorID: author})
+	}
+}
+
+func TestWatchLiveChanges(t *testing.T) {
+	hub := watch.NewHub(10)
+	publishEvents(hub, 1)
+
+	watcher, err := hub.Watch(0, nil)
+	if err != nil {
+		t.Fatalf("this is the error watching: %v\n", err)
+	}
+	defer watcher.Close()
+
+	hub.Publish(pbEvent.EventChange_DELETED, &pbEvent.EventDTO{ID: 1, AuthorID: 1})
+
+	change := <-watcher.C
+	assert.Equal(t, change.Revision, int64(2))
+	assert.Equal(t, change.ChangeType, pbEvent.EventChange_DELETED)
+	assert.Equal(t, len(watcher.C), 0)
+}
+
+func TestResumeFromRevision(t *testing.T) {
+	hub := watch.NewHub(10)
+	publishEvents(hub, 1, 2, 1, 2)
+
+	watcher, err := hub.Watch(1, func(change *pbEvent.EventChange) bool {
+		return change.Event.GetAuthorID() == 2
+	})
+	if err != nil {
+		t.Fatalf("this is the error resuming: %v\n", err)
+	}
+	defer watcher.Close()
+
+	assert.Equal(t, (<-watcher.C).Revision, int64(2))
+	assert.Equal(t, (<-watcher.C).Revision, int64(4))
+	assert.Equal(t, len(watcher.C), 0)
+}
+
+func TestResumeOutsideHistory(t *testing.T) {
+	hub := watch.NewHub(2)
+	publishEvents(hub, 1, 1, 1, 1)
+
+	_, err := hub.Watch(1, nil)
+	assert.Equal(t, err, watch.ErrCompacted)
+
+	_, err = hub.Watch(5, nil)
+	assert.Equal(t, err, watch.ErrFutureRevision)
+
+	watcher, err := hub.Watch(2, nil)
+	if err != nil {
+		t.Fatalf("this is the error resuming at the history boundary: %v\n", err)
+	}
+	defer watcher.Close()
+	assert.Equal(t, (<-watcher.C).Revision, int64(3))
+}
+
+func TestSlowWatcherIsDropped(t *testing.T) {
+	hub := watch.NewHub(10)
+
+	watcher, err := hub.Watch(0, nil)
+	if err != nil {
+		t.Fatalf("this is the error watching: %v\n", err)
+	}
+	for i := 0; i < 1000; i++ {
+		publishEvents(hub, 1)
+	}
+
+	received := 0
+	for range watcher.C {
+		received++
+	}
+	assert.NotEqual(t, received, 1000)
+	watcher.Close()
+}
+
+func TestCloseHub(t *testing.T) {
+	hub := watch.NewHub(10)
+
+	watcher, err := hub.Watch(0, nil)
+	if err != nil {
+		t.Fatalf("this is the error watching: %v\n", err)
+	}
+	hub.Close()
+
+	_, ok := <-watcher.C
+	assert.Equal(t, ok, false)
+	assert.Equal(t, watcher.Err(), watch.ErrClosed)
+	watcher.Close()
+
+	_, err = hub.Watch(0, nil)
+	assert.Equal(t, err, watch.ErrClosed)
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	_ "github.com/bufbuild/buf/cmd/buf"
+	_ "github.com/envoyproxy/protoc-gen-validate"
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway"
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2"
 	_ "github.com/rakyll/statik"